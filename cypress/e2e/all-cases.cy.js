@@ -63,4 +63,43 @@ describe("All cases", () => {
       .should("have.attr", "href")
       .should("contain", "/person/23/36");
   });
+
+  it("sorts by a chosen column", () => {
+    cy.addCaseFilterMock(
+      {
+        assigneeId: 104,
+        filter: "caseType:lpa,active:true",
+        sort: "donor.surname:desc",
+      },
+      [
+        {
+          caseSubtype: "hw",
+          donor: {
+            firstname: "Wilma",
+            id: 17,
+            surname: "Ruthman",
+            uId: "7000-5382-4438",
+          },
+          id: 58,
+          receiptDate: "14/05/2021",
+          status: "Pending",
+          uId: "7000-2830-9492",
+        },
+      ],
+    );
+
+    cy.visit("/all-cases?sort=donor.surname:desc");
+
+    cy.get("table > tbody > tr").should("contain", "Wilma Ruthman");
+
+    cy.get("th[aria-sort=descending]")
+      .should("contain", "Donor")
+      .find("a")
+      .should("have.attr", "href", "?sort=donor.surname%3Aasc");
+    cy.get("th")
+      .contains("Received")
+      .should("have.attr", "href", "?sort=receiptDate%3Aasc")
+      .parent()
+      .should("have.attr", "aria-sort", "none");
+  });
 });
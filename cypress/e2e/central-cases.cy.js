@@ -105,5 +105,75 @@ describe("Central pot pending cases", () => {
       .contains("7000-2830-9492")
       .should("have.attr", "href")
       .should("contain", "/person/17/58");
+
+    cy.get("[data-role=case-export]")
+      .contains("CSV")
+      .should("have.attr", "href", "?format=csv");
+    cy.get("[data-role=case-export]")
+      .contains("Excel")
+      .should("have.attr", "href", "?format=xlsx");
+  });
+
+  it("filters the central pot", () => {
+    cy.addMock("/lpa-api/v1/users/current", "GET", {
+      status: 200,
+      body: {
+        displayName: "Central Manager",
+        id: 293,
+        roles: ["Manager"],
+      },
+    });
+
+    cy.addMock(
+      "/lpa-api/v1/users?email=opgcasework@publicguardian.gov.uk",
+      "GET",
+      {
+        status: 200,
+        body: {
+          id: 14,
+        },
+      },
+    );
+
+    cy.addCaseFilterMock(
+      {
+        assigneeId: 14,
+        filter: "caseSubtype:hw,surname-initial:E,status:Pending,caseType:lpa,active:true",
+        sort: "receiptDate:asc",
+      },
+      [
+        {
+          caseSubtype: "hw",
+          donor: {
+            firstname: "Mario",
+            id: 363,
+            surname: "Evanosky",
+            uId: "7000-5382-4435",
+          },
+          id: 453,
+          receiptDate: "28/11/2017",
+          status: "Pending",
+          uId: "7000-2830-9429",
+        },
+      ],
+    );
+
+    cy.visit("/teams/central");
+
+    cy.get("#app-filters").should("not.be.visible");
+    cy.contains("button", "Show filters").click();
+
+    cy.get("#subtype-2").check();
+    cy.get("#surname-initial").select("E");
+    cy.contains("button", "Apply filters").click();
+
+    cy.url().should("contain", "caseSubtype=hw");
+    cy.url().should("contain", "surname-initial=E");
+    cy.get("#app-filters").should("be.visible");
+    cy.get("#surname-initial").should("have.value", "E");
+    cy.get("table > tbody > tr").should("contain", "Mario Evanosky");
+    cy.get("[data-role=case-export]")
+      .contains("CSV")
+      .should("have.attr", "href", "?caseSubtype=hw&surname-initial=E&format=csv");
   });
 });
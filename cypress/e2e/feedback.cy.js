@@ -34,4 +34,24 @@ describe("Feedback", () => {
 
     cy.url().should("include", "/all-cases");
   });
+
+  it("keeps feedback when Sirius cannot be reached", () => {
+    cy.visit("/feedback");
+
+    cy.get("textarea").type("Hey");
+    cy.get("label[for=rating-4]").click();
+
+    cy.addMock("/lpa-api/v1/feedback/poas", "POST", {
+      status: 503,
+    });
+
+    cy.contains("Submit").click();
+
+    cy.get(".govuk-notification-banner").should(
+      "contain",
+      "We could not reach Sirius",
+    );
+    cy.get("textarea").should("not.exist");
+    cy.contains("Submit").should("not.exist");
+  });
 });
@@ -56,4 +56,43 @@ describe("Pending cases", () => {
       "disabled",
     );
   });
+
+  it("expands a case to show its summary", () => {
+    cy.addMock("/lpa-api/v1/cases/58", "GET", {
+      status: 200,
+      body: {
+        id: 58,
+        uId: "7000-2830-9492",
+        donor: { id: 17, firstname: "Wilma", surname: "Ruthman" },
+        attorneys: [{ id: 18, firstname: "Fred", surname: "Ruthman" }],
+        workedDate: "20/05/2021",
+      },
+    });
+    cy.addMock(
+      {
+        path: "/lpa-api/v1/cases/58/tasks",
+        query: {
+          filter: "status:Not started,status:In progress",
+          sort: "dueDate:asc",
+        },
+      },
+      "GET",
+      {
+        status: 200,
+        body: { tasks: [{ id: 3, name: "Check application" }] },
+      },
+    );
+    cy.addMock("/lpa-api/v1/persons/17/warnings", "GET", {
+      status: 200,
+      body: [],
+    });
+
+    cy.contains("a", "Details").click();
+
+    cy.url().should("include", "/pending-cases");
+    const $summary = cy.get("[data-role=case-summary]");
+    $summary.should("contain", "Fred Ruthman");
+    $summary.should("contain", "Check application");
+    $summary.should("contain", "20 May 2021");
+  });
 });
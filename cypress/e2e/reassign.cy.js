@@ -98,4 +98,35 @@ describe("Reassign", () => {
       "Pending cases",
     );
   });
+
+  it("allows searching for a caseworker in another team", () => {
+    cy.get("tbody label[for=58]").click();
+
+    cy.addMock("/lpa-api/v1/teams/3", "GET", {
+      status: 200,
+      body: {
+        id: 3,
+        members: [],
+      },
+    });
+
+    cy.contains("Reassign or return selected case(s)").click();
+
+    cy.addMock("/lpa-api/v1/search/users?query=mary", "GET", {
+      status: 200,
+      body: [
+        {
+          id: 88,
+          displayName: "Mary Jones",
+          teams: [{ id: 9, displayName: "Team B" }],
+        },
+      ],
+    });
+
+    cy.contains("label", "Reassign").click();
+    cy.get("#caseworker-search").type("mary");
+    cy.contains("button", "Mary Jones (Team B)").click();
+
+    cy.get("#caseworker").should("have.value", "88");
+  });
 });
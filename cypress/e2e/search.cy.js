@@ -0,0 +1,67 @@
+describe("Search cases", () => {
+  beforeEach(() => {
+    cy.addMock("/lpa-api/v1/users/current", "GET", {
+      status: 200,
+      body: {
+        displayName: "A Manager",
+        id: 114,
+        roles: ["Manager"],
+      },
+    });
+
+    cy.addMock(
+      "/lpa-api/v1/search/cases?caseType=lpa&query=7000-2830-9492",
+      "GET",
+      {
+        status: 200,
+        body: {
+          cases: [
+            {
+              caseSubtype: "hw",
+              donor: {
+                firstname: "Wilma",
+                id: 17,
+                surname: "Ruthman",
+                uId: "7000-5382-4438",
+              },
+              id: 58,
+              receiptDate: "14/05/2021",
+              status: "Pending",
+              uId: "7000-2830-9492",
+              assignee: {
+                id: 47,
+                displayName: "John Paulson",
+              },
+            },
+          ],
+        },
+      },
+    );
+
+    cy.visit("/search");
+  });
+
+  it("finds a case by UID from the header", () => {
+    cy.get("#header-search").type("700028309492{enter}");
+
+    cy.url().should("include", "/search?q=700028309492");
+    cy.get("h1").should("contain", "Search cases");
+
+    const $row = cy.get("[data-role=search-results] tbody tr");
+    $row.should("have.length", 1);
+    $row.should("contain", "Wilma Ruthman");
+    $row.should("contain", "7000-2830-9492");
+    $row.should("contain", "HW");
+    $row.should("contain", "14 May 2021");
+    $row.should("contain", "Pending");
+    $row.should("contain", "John Paulson");
+  });
+
+  it("links managers to reassign a result", () => {
+    cy.get("#search-q").type("7000-2830-9492{enter}");
+
+    cy.contains("a", "Reassign")
+      .should("have.attr", "href")
+      .and("include", "/reassign?assignee=47&selected=58");
+  });
+});
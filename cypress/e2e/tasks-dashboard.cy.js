@@ -12,7 +12,7 @@ describe("Tasks dashboard", () => {
     cy.addTaskFilterMock(
       {
         assigneeId: 106,
-        filter: "status:Not started",
+        filter: "status:Not started,status:In progress",
         sort: "dueDate:asc,name:desc",
       },
       [
@@ -51,5 +51,7 @@ describe("Tasks dashboard", () => {
       .contains("7000-8548-8461")
       .should("have.attr", "href")
       .should("contain", "/person/23/1");
+    $row.contains("button", "Start");
+    $row.contains("button", "Complete");
   });
 });
@@ -53,4 +53,47 @@ describe("Tasks", () => {
       .should("have.attr", "href")
       .should("contain", "/person/17/58");
   });
+
+  it("remembers the page size chosen", () => {
+    const cases = (limit) => ({
+      status: 200,
+      body: {
+        cases: [],
+        limit,
+        pages: {
+          current: 1,
+          total: 1,
+        },
+        total: 0,
+      },
+    });
+
+    cy.addMock(
+      "/lpa-api/v1/assignees/104/cases-with-open-tasks?page=1",
+      "GET",
+      cases(25),
+    );
+    cy.addMock(
+      "/lpa-api/v1/assignees/104/cases-with-open-tasks?page=1&limit=50",
+      "GET",
+      cases(50),
+    );
+
+    cy.addCaseFilterMock({
+      assigneeId: 104,
+      filter: "status:Pending,worked:false,caseType:lpa,active:true",
+      limit: 1,
+    });
+
+    cy.visit("/tasks");
+
+    cy.get("[data-role=page-size] [aria-current]").should("contain", "25");
+    cy.get("[data-role=page-size]").contains("a", "50").click();
+
+    cy.url().should("contain", "page-size=50");
+    cy.get("[data-role=page-size] [aria-current]").should("contain", "50");
+
+    cy.visit("/tasks");
+    cy.get("[data-role=page-size] [aria-current]").should("contain", "50");
+  });
 });
@@ -239,4 +239,23 @@ describe("Team work in progress", () => {
     );
     cy.get(".moj-ticket-panel").should("contain", "Nottingham casework team");
   });
+
+  it("can save and delete filter presets", () => {
+    cy.setCookie("XSRF-TOKEN", "abcde");
+    cy.visit("/teams/work-in-progress/66");
+
+    cy.contains("Show filters").click();
+    cy.get("#preset-name").type("Everything");
+    cy.contains("button", "Save filters").click();
+
+    cy.url().should("contain", "page=1");
+    cy.get("[data-role=filter-presets]")
+      .contains("Everything")
+      .should("have.attr", "aria-current", "true");
+
+    cy.contains("Show filters").click();
+    cy.contains("button", "Delete Everything").click();
+
+    cy.get("[data-role=filter-presets]").should("not.exist");
+  });
 });
@@ -51,6 +51,36 @@ describe("Another user's tasks", () => {
       },
     });
 
+    cy.addTaskFilterMock(
+      {
+        assigneeId: 47,
+        filter: "status:Not started,status:In progress",
+        sort: "dueDate:asc",
+        page: 1,
+        limit: 100,
+      },
+      [
+        {
+          caseItems: [
+            {
+              caseSubtype: "hw",
+              donor: {
+                firstname: "Wilma",
+                id: 17,
+                surname: "Ruthman",
+              },
+              id: 58,
+              uId: "7000-2830-9492",
+            },
+          ],
+          dueDate: "19/05/2021",
+          id: 36,
+          name: "Review application",
+          status: "Not started",
+        },
+      ],
+    );
+
     cy.visit("/users/tasks/47");
 
     cy.title().should("contain", "John");
@@ -70,5 +100,10 @@ describe("Another user's tasks", () => {
       .should("have.attr", "href")
       .should("contain", "/person/17/58");
     $row.get(".govuk-tag").should("contain", "Pending");
+
+    cy.get("h2").should("contain", "Open tasks");
+    cy.get("label[for=task-36]").click();
+    cy.contains("button", "Reassign or return selected task(s)").click();
+    cy.url().should("contain", "/reassign-tasks?assignee=47&selected=36");
   });
 });
@@ -0,0 +1,43 @@
+package jsonfile
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWriteRead(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "file.json")
+
+	assert.Nil(t, Write(path, map[string]int{"a": 1}))
+
+	data, _ := os.ReadFile(path)
+	assert.Equal(t, `{"a":1}`, string(data))
+
+	var v map[string]int
+	assert.Nil(t, Read(path, &v))
+	assert.Equal(t, map[string]int{"a": 1}, v)
+
+	entries, _ := os.ReadDir(filepath.Dir(path))
+	assert.Len(t, entries, 1)
+}
+
+func TestReadMissing(t *testing.T) {
+	v := []int{1}
+	assert.Nil(t, Read(filepath.Join(t.TempDir(), "missing.json"), &v))
+	assert.Equal(t, []int{1}, v)
+}
+
+func TestReadInvalid(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "file.json")
+	_ = os.WriteFile(path, []byte("{"), 0o600)
+
+	var v map[string]int
+	assert.NotNil(t, Read(path, &v))
+}
+
+func TestWriteError(t *testing.T) {
+	assert.NotNil(t, Write(filepath.Join(t.TempDir(), "missing", "file.json"), 1))
+}
@@ -0,0 +1,110 @@
+// Package presets stores managers' saved team work in progress filters.
+package presets
+
+import (
+	"context"
+	"slices"
+	"strconv"
+	"sync"
+
+	"github.com/ministryofjustice/opg-sirius-lpa-dashboard/internal/jsonfile"
+)
+
+type Preset struct {
+	Name    string `json:"name"`
+	Query   string `json:"query"`
+	Default bool   `json:"default,omitempty"`
+}
+
+// FileStore only holds presets in memory when it has no path.
+type FileStore struct {
+	path string
+
+	mu      sync.Mutex
+	presets map[string][]Preset
+}
+
+func NewFileStore(path string) (*FileStore, error) {
+	s := &FileStore{path: path, presets: map[string][]Preset{}}
+	if path == "" {
+		return s, nil
+	}
+
+	if err := jsonfile.Read(path, &s.presets); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+func (s *FileStore) List(ctx context.Context, userID int) ([]Preset, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return slices.Clone(s.presets[strconv.Itoa(userID)]), nil
+}
+
+// Save unsets the user's other default when the preset is the default.
+func (s *FileStore) Save(ctx context.Context, userID int, preset Preset) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := strconv.Itoa(userID)
+	list := slices.Clone(s.presets[key])
+
+	if preset.Default {
+		for i := range list {
+			list[i].Default = false
+		}
+	}
+
+	if i := slices.IndexFunc(list, func(p Preset) bool { return p.Name == preset.Name }); i >= 0 {
+		list[i] = preset
+	} else {
+		list = append(list, preset)
+	}
+
+	return s.update(key, list)
+}
+
+func (s *FileStore) Delete(ctx context.Context, userID int, name string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := strconv.Itoa(userID)
+	list := slices.DeleteFunc(slices.Clone(s.presets[key]), func(p Preset) bool { return p.Name == name })
+
+	return s.update(key, list)
+}
+
+// update only keeps the change if it could be written. It must be called with
+// mu held.
+func (s *FileStore) update(key string, list []Preset) error {
+	previous, existed := s.presets[key]
+
+	if len(list) == 0 {
+		delete(s.presets, key)
+	} else {
+		s.presets[key] = list
+	}
+
+	if err := s.write(); err != nil {
+		if existed {
+			s.presets[key] = previous
+		} else {
+			delete(s.presets, key)
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+func (s *FileStore) write() error {
+	if s.path == "" {
+		return nil
+	}
+
+	return jsonfile.Write(s.path, s.presets)
+}
@@ -0,0 +1,100 @@
+package presets
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFileStore(t *testing.T) {
+	assert := assert.New(t)
+	ctx := context.Background()
+
+	path := filepath.Join(t.TempDir(), "presets.json")
+
+	store, err := NewFileStore(path)
+	assert.Nil(err)
+
+	list, err := store.List(ctx, 1)
+	assert.Nil(err)
+	assert.Empty(list)
+
+	assert.Nil(store.Save(ctx, 1, Preset{Name: "Mine", Query: "allocation=1", Default: true}))
+	assert.Nil(store.Save(ctx, 1, Preset{Name: "Worked", Query: "status=pending-worked"}))
+	assert.Nil(store.Save(ctx, 2, Preset{Name: "Mine", Query: "allocation=2"}))
+
+	list, _ = store.List(ctx, 1)
+	assert.Equal([]Preset{
+		{Name: "Mine", Query: "allocation=1", Default: true},
+		{Name: "Worked", Query: "status=pending-worked"},
+	}, list)
+
+	assert.Nil(store.Save(ctx, 1, Preset{Name: "Worked", Query: "status=pending-worked&lpa-type=hw", Default: true}))
+
+	list, _ = store.List(ctx, 1)
+	assert.Equal([]Preset{
+		{Name: "Mine", Query: "allocation=1"},
+		{Name: "Worked", Query: "status=pending-worked&lpa-type=hw", Default: true},
+	}, list)
+
+	assert.Nil(store.Delete(ctx, 1, "Mine"))
+	assert.Nil(store.Delete(ctx, 1, "Missing"))
+
+	reopened, err := NewFileStore(path)
+	assert.Nil(err)
+
+	list, _ = reopened.List(ctx, 1)
+	assert.Equal([]Preset{{Name: "Worked", Query: "status=pending-worked&lpa-type=hw", Default: true}}, list)
+
+	list, _ = reopened.List(ctx, 2)
+	assert.Equal([]Preset{{Name: "Mine", Query: "allocation=2"}}, list)
+}
+
+func TestFileStoreListIsACopy(t *testing.T) {
+	ctx := context.Background()
+
+	store, _ := NewFileStore("")
+	_ = store.Save(ctx, 1, Preset{Name: "Mine", Query: "allocation=1"})
+
+	list, _ := store.List(ctx, 1)
+	list[0].Name = "Changed"
+
+	list, _ = store.List(ctx, 1)
+	assert.Equal(t, "Mine", list[0].Name)
+}
+
+func TestFileStoreInMemory(t *testing.T) {
+	assert := assert.New(t)
+	ctx := context.Background()
+
+	store, err := NewFileStore("")
+	assert.Nil(err)
+
+	assert.Nil(store.Save(ctx, 1, Preset{Name: "Mine", Query: "allocation=1"}))
+
+	list, _ := store.List(ctx, 1)
+	assert.Equal([]Preset{{Name: "Mine", Query: "allocation=1"}}, list)
+}
+
+func TestNewFileStoreBadFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "presets.json")
+	_ = os.WriteFile(path, []byte("not json"), 0o600)
+
+	_, err := NewFileStore(path)
+	assert.NotNil(t, err)
+}
+
+func TestFileStoreWriteError(t *testing.T) {
+	assert := assert.New(t)
+	ctx := context.Background()
+
+	store, _ := NewFileStore(filepath.Join(t.TempDir(), "missing", "presets.json"))
+
+	assert.NotNil(store.Save(ctx, 1, Preset{Name: "Mine", Query: "allocation=1"}))
+
+	list, _ := store.List(ctx, 1)
+	assert.Empty(list)
+}
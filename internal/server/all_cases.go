@@ -13,15 +13,16 @@ type AllCasesClient interface {
 }
 
 type allCasesVars struct {
-	Cases           []sirius.Case
-	Pagination      *Pagination
-	HasWorkableCase bool
-	CanRequestCase  bool
-	IsManager       bool
-	XSRFToken       string
+	Cases           []sirius.Case `json:"cases"`
+	Pagination      *Pagination   `json:"pagination"`
+	HasWorkableCase bool          `json:"hasWorkableCase"`
+	CanRequestCase  bool          `json:"canRequestCase"`
+	IsManager       bool          `json:"isManager"`
+	XSRFToken       string        `json:"-"`
+	Sort            Sort          `json:"sort"`
 }
 
-func allCases(client AllCasesClient, tmpl Template) Handler {
+func allCases(client AllCasesClient, tmpl Template, prefix string) Handler {
 	return func(w http.ResponseWriter, r *http.Request) error {
 		if r.Method != http.MethodGet {
 			return StatusError(http.StatusMethodNotAllowed)
@@ -34,7 +35,24 @@ func allCases(client AllCasesClient, tmpl Template) Handler {
 			return err
 		}
 
-		myCases, pagination, err := client.CasesByAssignee(ctx, myDetails.ID, sirius.Criteria{}.Page(getPage(r)).Sort("receiptDate", sirius.Ascending))
+		chosen, err := caseSortSchema.Parse(r.URL.Query())
+		if err != nil {
+			return err
+		}
+
+		pageSize, err := getPageSize(w, r, prefix)
+		if err != nil {
+			return err
+		}
+
+		criteria := chosen
+		if !criteria.IsSorted() {
+			criteria = criteria.Sort("receiptDate", sirius.Ascending)
+		}
+
+		criteria = withPageSize(criteria.Page(getPage(r)), pageSize)
+
+		myCases, pagination, err := client.CasesByAssignee(ctx, myDetails.ID, criteria)
 		if err != nil {
 			return err
 		}
@@ -46,11 +64,12 @@ func allCases(client AllCasesClient, tmpl Template) Handler {
 
 		vars := allCasesVars{
 			Cases:           myCases,
-			Pagination:      newPagination(pagination),
+			Pagination:      newPaginationWithQuery(pagination, caseSortSchema.Encode(chosen).Encode()),
 			HasWorkableCase: hasWorkableCase,
 			CanRequestCase:  myDetails.HasRole("Self Allocation User"),
 			IsManager:       myDetails.HasRole("Manager"),
 			XSRFToken:       ctx.XSRFToken,
+			Sort:            newSort(caseSortSchema, chosen, criteria),
 		}
 
 		return tmpl.ExecuteTemplate(w, "page", vars)
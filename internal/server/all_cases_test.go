@@ -78,7 +78,7 @@ func TestGetAllCases(t *testing.T) {
 	w := httptest.NewRecorder()
 	r, _ := http.NewRequest("GET", "/path", nil)
 
-	err := allCases(client, template)(w, r)
+	err := allCases(client, template, "")(w, r)
 	assert.Nil(err)
 
 	assert.Equal(1, client.myDetails.count)
@@ -98,6 +98,7 @@ func TestGetAllCases(t *testing.T) {
 	assert.Equal(allCasesVars{
 		Cases:           client.casesByAssignee.data,
 		HasWorkableCase: true,
+		Sort:            Sort{Field: "receiptDate", Order: "asc"},
 	}, template.lastVars)
 }
 
@@ -106,7 +107,7 @@ func TestGetAllCasesPage(t *testing.T) {
 
 	client := &mockAllCasesClient{}
 	client.myDetails.data = sirius.MyDetails{
-		ID: 14,
+		ID:    14,
 		Roles: []string{"Self Allocation User"},
 	}
 	client.casesByAssignee.data = []sirius.Case{{
@@ -120,7 +121,7 @@ func TestGetAllCasesPage(t *testing.T) {
 	w := httptest.NewRecorder()
 	r, _ := http.NewRequest("GET", "/path?page=4", nil)
 
-	err := allCases(client, template)(w, r)
+	err := allCases(client, template, "")(w, r)
 	assert.Nil(err)
 
 	assert.Equal(1, client.myDetails.count)
@@ -135,10 +136,50 @@ func TestGetAllCasesPage(t *testing.T) {
 	assert.Equal("page", template.lastName)
 	assert.Equal(allCasesVars{
 		CanRequestCase: true,
-		Cases: client.casesByAssignee.data,
+		Cases:          client.casesByAssignee.data,
+		Sort:           Sort{Field: "receiptDate", Order: "asc"},
 	}, template.lastVars)
 }
 
+func TestGetAllCasesSorted(t *testing.T) {
+	assert := assert.New(t)
+
+	client := &mockAllCasesClient{}
+	client.myDetails.data = sirius.MyDetails{
+		ID: 14,
+	}
+	client.casesByAssignee.pagination = &sirius.Pagination{TotalPages: 2}
+	template := &mockTemplate{}
+
+	w := httptest.NewRecorder()
+	r, _ := http.NewRequest("GET", "/path?sort=donor.surname:desc&page=2", nil)
+
+	err := allCases(client, template, "")(w, r)
+	assert.Nil(err)
+
+	assert.Equal(sirius.Criteria{}.Page(2).Sort("donor.surname", sirius.Descending), client.casesByAssignee.lastCriteria)
+
+	vars := template.lastVars.(allCasesVars)
+	assert.Equal("?sort=donor.surname%3Adesc&", vars.Pagination.Query)
+	assert.Equal(Sort{Field: "donor.surname", Order: "desc", Chosen: "donor.surname:desc"}, vars.Sort)
+}
+
+func TestGetAllCasesBadSort(t *testing.T) {
+	assert := assert.New(t)
+
+	client := &mockAllCasesClient{}
+	template := &mockTemplate{}
+
+	w := httptest.NewRecorder()
+	r, _ := http.NewRequest("GET", "/path?sort=assignee:asc", nil)
+
+	err := allCases(client, template, "")(w, r)
+	assert.IsType(sirius.CriteriaError{}, err)
+
+	assert.Equal(0, client.casesByAssignee.count)
+	assert.Equal(0, template.count)
+}
+
 func TestGetAllCasesMyDetailsError(t *testing.T) {
 	assert := assert.New(t)
 
@@ -151,7 +192,7 @@ func TestGetAllCasesMyDetailsError(t *testing.T) {
 	w := httptest.NewRecorder()
 	r, _ := http.NewRequest("GET", "/path", nil)
 
-	err := allCases(client, template)(w, r)
+	err := allCases(client, template, "")(w, r)
 	assert.Equal(expectedError, err)
 
 	assert.Equal(1, client.myDetails.count)
@@ -175,7 +216,7 @@ func TestGetAllCasesQueryError(t *testing.T) {
 	w := httptest.NewRecorder()
 	r, _ := http.NewRequest("GET", "/path", nil)
 
-	err := allCases(client, template)(w, r)
+	err := allCases(client, template, "")(w, r)
 	assert.Equal(expectedError, err)
 
 	assert.Equal(1, client.myDetails.count)
@@ -196,7 +237,7 @@ func TestBadMethodAllCases(t *testing.T) {
 	w := httptest.NewRecorder()
 	r, _ := http.NewRequest("DELETE", "/path", nil)
 
-	err := allCases(client, template)(w, r)
+	err := allCases(client, template, "")(w, r)
 
 	assert.Equal(StatusError(http.StatusMethodNotAllowed), err)
 
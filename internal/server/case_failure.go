@@ -0,0 +1,40 @@
+package server
+
+import (
+	"errors"
+	"slices"
+	"strings"
+
+	"github.com/ministryofjustice/opg-sirius-lpa-dashboard/internal/sirius"
+)
+
+// caseFailure explains why a bulk action did not succeed for a single case.
+type caseFailure struct {
+	ID     int    `json:"id"`
+	Reason string `json:"reason"`
+}
+
+func newCaseFailure(id int, err error, fallback string) caseFailure {
+	return caseFailure{ID: id, Reason: problemReason(err, fallback)}
+}
+
+// problemReason explains err using the title and mapped field errors returned
+// by Sirius, never the detail, which can contain donor details.
+func problemReason(err error, fallback string) string {
+	var statusError *sirius.StatusError
+	if !errors.As(err, &statusError) || statusError.Problem == nil || statusError.Problem.Title == "" {
+		return fallback
+	}
+
+	var fields []string
+	for _, fieldError := range fieldRuleErrors(statusError.FieldErrors()) {
+		fields = append(fields, fieldError.Field+" "+fieldError.Message)
+	}
+
+	if len(fields) == 0 {
+		return statusError.Title()
+	}
+
+	slices.Sort(fields)
+	return statusError.Title() + ": " + strings.Join(fields, ", ")
+}
@@ -0,0 +1,52 @@
+package server
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ministryofjustice/opg-sirius-lpa-dashboard/internal/sirius"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProblemReason(t *testing.T) {
+	testCases := map[string]struct {
+		err    error
+		reason string
+	}{
+		"not from Sirius": {
+			err:    errors.New("hmm"),
+			reason: "fallback",
+		},
+		"no problem": {
+			err:    &sirius.StatusError{Code: 500},
+			reason: "fallback",
+		},
+		"no title": {
+			err:    &sirius.StatusError{Code: 400, Problem: &sirius.Problem{Detail: "Could not load donor Adrian Kurkjian"}},
+			reason: "fallback",
+		},
+		"title": {
+			err:    &sirius.StatusError{Code: 400, Problem: &sirius.Problem{Title: "Bad request", Detail: "Could not load donor Adrian Kurkjian"}},
+			reason: "Bad request",
+		},
+		"field errors": {
+			err: &sirius.StatusError{Code: 400, Problem: &sirius.Problem{
+				Title:  "Bad request",
+				Detail: "Could not load donor Adrian Kurkjian",
+				ValidationErrors: map[string]map[string]string{
+					"donor":      {"unknownRule": "Adrian Kurkjian is not valid"},
+					"assigneeId": {"isEmpty": "Value is required and can't be empty"},
+				},
+			}},
+			reason: "Bad request: assigneeId is required, donor is not valid",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			reason := problemReason(tc.err, "fallback")
+			assert.Equal(t, tc.reason, reason)
+			assert.NotContains(t, reason, "Kurkjian")
+		})
+	}
+}
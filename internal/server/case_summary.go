@@ -0,0 +1,79 @@
+package server
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/ministryofjustice/opg-sirius-lpa-dashboard/internal/sirius"
+)
+
+type CaseSummaryClient interface {
+	CaseSummary(sirius.Context, int) (sirius.CaseSummary, error)
+	TasksByCase(sirius.Context, int, sirius.Criteria) ([]sirius.Task, error)
+	Warnings(sirius.Context, int) ([]sirius.Warning, error)
+}
+
+type caseSummaryVars struct {
+	Case     sirius.CaseSummary `json:"case"`
+	Tasks    []sirius.Task      `json:"tasks"`
+	Warnings []sirius.Warning   `json:"warnings"`
+}
+
+// caseSummary shows the details of a case that do not fit in the case tables.
+// Requests made by main.js get just the "case-summary" fragment, to expand a
+// row in place; anything else gets a full page.
+func caseSummary(client CaseSummaryClient, tmpl Template) Handler {
+	return func(w http.ResponseWriter, r *http.Request) error {
+		if r.Method != http.MethodGet {
+			return StatusError(http.StatusMethodNotAllowed)
+		}
+
+		path, ok := strings.CutSuffix(strings.TrimPrefix(r.URL.Path, "/cases/"), "/summary")
+		if !ok {
+			return StatusError(http.StatusNotFound)
+		}
+
+		id, err := strconv.Atoi(path)
+		if err != nil {
+			return StatusError(http.StatusNotFound)
+		}
+
+		ctx := getContext(r)
+
+		summary, err := client.CaseSummary(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		taskCriteria := sirius.Criteria{}.
+			Filter("status", sirius.TaskNotStarted).
+			Filter("status", sirius.TaskInProgress).
+			Sort("dueDate", sirius.Ascending)
+
+		tasks, err := client.TasksByCase(ctx, id, taskCriteria)
+		if err != nil {
+			return err
+		}
+
+		var warnings []sirius.Warning
+		if summary.Donor.ID != 0 {
+			warnings, err = client.Warnings(ctx, summary.Donor.ID)
+			if err != nil {
+				return err
+			}
+		}
+
+		vars := caseSummaryVars{
+			Case:     summary,
+			Tasks:    tasks,
+			Warnings: warnings,
+		}
+
+		if r.Header.Get("X-Requested-With") == "XMLHttpRequest" {
+			return tmpl.ExecuteTemplate(w, "case-summary", vars)
+		}
+
+		return tmpl.ExecuteTemplate(w, "page", vars)
+	}
+}
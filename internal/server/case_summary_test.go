@@ -0,0 +1,193 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ministryofjustice/opg-sirius-lpa-dashboard/internal/sirius"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockCaseSummaryClient struct {
+	caseSummary struct {
+		count   int
+		lastCtx sirius.Context
+		lastId  int
+		data    sirius.CaseSummary
+		err     error
+	}
+	tasksByCase struct {
+		count        int
+		lastCtx      sirius.Context
+		lastId       int
+		lastCriteria sirius.Criteria
+		data         []sirius.Task
+		err          error
+	}
+	warnings struct {
+		count   int
+		lastCtx sirius.Context
+		lastId  int
+		data    []sirius.Warning
+		err     error
+	}
+}
+
+func (m *mockCaseSummaryClient) CaseSummary(ctx sirius.Context, id int) (sirius.CaseSummary, error) {
+	m.caseSummary.count += 1
+	m.caseSummary.lastCtx = ctx
+	m.caseSummary.lastId = id
+
+	return m.caseSummary.data, m.caseSummary.err
+}
+
+func (m *mockCaseSummaryClient) TasksByCase(ctx sirius.Context, id int, criteria sirius.Criteria) ([]sirius.Task, error) {
+	m.tasksByCase.count += 1
+	m.tasksByCase.lastCtx = ctx
+	m.tasksByCase.lastId = id
+	m.tasksByCase.lastCriteria = criteria
+
+	return m.tasksByCase.data, m.tasksByCase.err
+}
+
+func (m *mockCaseSummaryClient) Warnings(ctx sirius.Context, id int) ([]sirius.Warning, error) {
+	m.warnings.count += 1
+	m.warnings.lastCtx = ctx
+	m.warnings.lastId = id
+
+	return m.warnings.data, m.warnings.err
+}
+
+func TestGetCaseSummary(t *testing.T) {
+	assert := assert.New(t)
+
+	client := &mockCaseSummaryClient{}
+	client.caseSummary.data = sirius.CaseSummary{
+		Case: sirius.Case{ID: 36, Donor: sirius.Donor{ID: 23}},
+	}
+	client.tasksByCase.data = []sirius.Task{{ID: 12, Name: "Check application"}}
+	client.warnings.data = []sirius.Warning{{ID: 5, WarningType: "Safeguarding"}}
+	template := &mockTemplate{}
+
+	w := httptest.NewRecorder()
+	r, _ := http.NewRequest("GET", "/cases/36/summary", nil)
+
+	err := caseSummary(client, template)(w, r)
+	assert.Nil(err)
+
+	assert.Equal(1, client.caseSummary.count)
+	assert.Equal(getContext(r), client.caseSummary.lastCtx)
+	assert.Equal(36, client.caseSummary.lastId)
+
+	assert.Equal(1, client.tasksByCase.count)
+	assert.Equal(36, client.tasksByCase.lastId)
+	assert.Equal(sirius.Criteria{}.Filter("status", sirius.TaskNotStarted).Filter("status", sirius.TaskInProgress).Sort("dueDate", sirius.Ascending), client.tasksByCase.lastCriteria)
+
+	assert.Equal(1, client.warnings.count)
+	assert.Equal(23, client.warnings.lastId)
+
+	assert.Equal(1, template.count)
+	assert.Equal("page", template.lastName)
+	assert.Equal(caseSummaryVars{
+		Case:     client.caseSummary.data,
+		Tasks:    client.tasksByCase.data,
+		Warnings: client.warnings.data,
+	}, template.lastVars)
+}
+
+func TestGetCaseSummaryFragment(t *testing.T) {
+	assert := assert.New(t)
+
+	client := &mockCaseSummaryClient{}
+	client.caseSummary.data = sirius.CaseSummary{
+		Case: sirius.Case{ID: 36, Donor: sirius.Donor{ID: 23}},
+	}
+	template := &mockTemplate{}
+
+	w := httptest.NewRecorder()
+	r, _ := http.NewRequest("GET", "/cases/36/summary", nil)
+	r.Header.Set("X-Requested-With", "XMLHttpRequest")
+
+	err := caseSummary(client, template)(w, r)
+	assert.Nil(err)
+
+	assert.Equal(1, template.count)
+	assert.Equal("case-summary", template.lastName)
+}
+
+func TestGetCaseSummaryWithoutDonor(t *testing.T) {
+	assert := assert.New(t)
+
+	client := &mockCaseSummaryClient{}
+	client.caseSummary.data = sirius.CaseSummary{Case: sirius.Case{ID: 36}}
+	template := &mockTemplate{}
+
+	w := httptest.NewRecorder()
+	r, _ := http.NewRequest("GET", "/cases/36/summary", nil)
+
+	err := caseSummary(client, template)(w, r)
+	assert.Nil(err)
+
+	assert.Equal(0, client.warnings.count)
+	assert.Equal(1, template.count)
+}
+
+func TestGetCaseSummaryNotFound(t *testing.T) {
+	for _, path := range []string{"/cases/36", "/cases/abc/summary", "/cases/36/summary/more"} {
+		t.Run(path, func(t *testing.T) {
+			client := &mockCaseSummaryClient{}
+			template := &mockTemplate{}
+
+			w := httptest.NewRecorder()
+			r, _ := http.NewRequest("GET", path, nil)
+
+			err := caseSummary(client, template)(w, r)
+			assert.Equal(t, StatusError(http.StatusNotFound), err)
+			assert.Equal(t, 0, client.caseSummary.count)
+		})
+	}
+}
+
+func TestGetCaseSummaryErrors(t *testing.T) {
+	expectedError := errors.New("oops")
+
+	testCases := map[string]func(*mockCaseSummaryClient){
+		"case":     func(m *mockCaseSummaryClient) { m.caseSummary.err = expectedError },
+		"tasks":    func(m *mockCaseSummaryClient) { m.tasksByCase.err = expectedError },
+		"warnings": func(m *mockCaseSummaryClient) { m.warnings.err = expectedError },
+	}
+
+	for name, setup := range testCases {
+		t.Run(name, func(t *testing.T) {
+			client := &mockCaseSummaryClient{}
+			client.caseSummary.data = sirius.CaseSummary{Case: sirius.Case{ID: 36, Donor: sirius.Donor{ID: 23}}}
+			setup(client)
+			template := &mockTemplate{}
+
+			w := httptest.NewRecorder()
+			r, _ := http.NewRequest("GET", "/cases/36/summary", nil)
+
+			err := caseSummary(client, template)(w, r)
+			assert.Equal(t, expectedError, err)
+			assert.Equal(t, 0, template.count)
+		})
+	}
+}
+
+func TestBadMethodCaseSummary(t *testing.T) {
+	assert := assert.New(t)
+
+	client := &mockCaseSummaryClient{}
+	template := &mockTemplate{}
+
+	w := httptest.NewRecorder()
+	r, _ := http.NewRequest("POST", "/cases/36/summary", nil)
+
+	err := caseSummary(client, template)(w, r)
+	assert.Equal(StatusError(http.StatusMethodNotAllowed), err)
+
+	assert.Equal(0, client.caseSummary.count)
+	assert.Equal(0, template.count)
+}
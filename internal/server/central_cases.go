@@ -1,27 +1,99 @@
 package server
 
 import (
+	"iter"
 	"net/http"
+	"net/url"
+	"strings"
+	"time"
 
 	"github.com/ministryofjustice/opg-sirius-lpa-dashboard/internal/sirius"
 )
 
 type CentralCasesClient interface {
+	AllCasesByAssignee(sirius.Context, int, sirius.Criteria) iter.Seq2[sirius.Case, error]
 	CasesByAssignee(sirius.Context, int, sirius.Criteria) ([]sirius.Case, *sirius.Pagination, error)
 	MyDetails(sirius.Context) (sirius.MyDetails, error)
 	UserByEmail(sirius.Context, string) (sirius.User, error)
 }
 
 type centralCasesVars struct {
-	Cases          []sirius.Case
-	OldestCaseDate sirius.SiriusDate
-	Pagination     *Pagination
-	TeamID         int
-	TeamName       string
-	IsCaseWorker   bool
+	Cases          []sirius.Case       `json:"cases"`
+	OldestCaseDate sirius.SiriusDate   `json:"oldestCaseDate,omitzero"`
+	Pagination     *Pagination         `json:"pagination"`
+	TeamID         int                 `json:"teamId"`
+	TeamName       string              `json:"teamName"`
+	IsCaseWorker   bool                `json:"isCaseWorker"`
+	Sort           Sort                `json:"sort"`
+	ExportQuery    string              `json:"-"`
+	Filters        centralCasesFilters `json:"filters"`
 }
 
-func centralCases(client CentralCasesClient, tmpl Template) Handler {
+var centralCasesSchema = sirius.CriteriaSchema{
+	Filters: []sirius.FilterField{
+		{Name: "date-from", Type: sirius.DateFilter},
+		{Name: "date-to", Type: sirius.DateFilter},
+		{Name: "lpa-type", Values: []string{"pfa", "hw", "both"}},
+		{Name: "caseSubtype", Multiple: true, Values: []string{"pfa", "hw"}},
+		{Name: "surname-initial", Values: strings.Split("ABCDEFGHIJKLMNOPQRSTUVWXYZ", "")},
+	},
+	Sorts: caseSortFields,
+}
+
+// centralCasesFilters narrow the central pot. LpaType works as it does on
+// team work in progress, matching on the donor's other LPAs, whereas Subtype
+// only looks at the case itself.
+type centralCasesFilters struct {
+	Set            bool      `json:"set"`
+	DateFrom       time.Time `json:"dateFrom,omitzero"`
+	DateTo         time.Time `json:"dateTo,omitzero"`
+	LpaType        string    `json:"lpaType"`
+	Subtype        []string  `json:"subtype"`
+	SurnameInitial string    `json:"surnameInitial"`
+
+	criteria sirius.Criteria
+}
+
+func (f centralCasesFilters) Encode() string {
+	return centralCasesSchema.Encode(f.criteria).Encode()
+}
+
+func (f centralCasesFilters) Criteria() sirius.Criteria {
+	return f.criteria
+}
+
+func newCentralCasesFilters(form url.Values) (centralCasesFilters, error) {
+	criteria, err := centralCasesSchema.Parse(form)
+	if err != nil {
+		return centralCasesFilters{}, err
+	}
+
+	filters := centralCasesFilters{
+		Set:      criteria.IsFiltered(),
+		Subtype:  criteria.FilterValues("caseSubtype"),
+		criteria: criteria,
+	}
+
+	for _, v := range criteria.FilterValues("date-from") {
+		filters.DateFrom, _ = time.Parse("2006-01-02", v)
+	}
+
+	for _, v := range criteria.FilterValues("date-to") {
+		filters.DateTo, _ = time.Parse("2006-01-02", v)
+	}
+
+	for _, v := range criteria.FilterValues("lpa-type") {
+		filters.LpaType = v
+	}
+
+	for _, v := range criteria.FilterValues("surname-initial") {
+		filters.SurnameInitial = v
+	}
+
+	return filters, nil
+}
+
+func centralCases(client CentralCasesClient, tmpl Template, prefix string) Handler {
 	return func(w http.ResponseWriter, r *http.Request) error {
 		if r.Method != http.MethodGet {
 			return StatusError(http.StatusMethodNotAllowed)
@@ -38,29 +110,60 @@ func centralCases(client CentralCasesClient, tmpl Template) Handler {
 			return StatusError(http.StatusForbidden)
 		}
 
+		format, err := getExportFormat(r)
+		if err != nil {
+			return err
+		}
+
+		pageSize, err := getPageSize(w, r, prefix)
+		if err != nil {
+			return err
+		}
+
+		filters, err := newCentralCasesFilters(r.URL.Query())
+		if err != nil {
+			return err
+		}
+
+		criteria := filters.Criteria()
+		if !criteria.IsSorted() {
+			criteria = criteria.Sort("receiptDate", sirius.Ascending)
+		}
+
+		criteria = criteria.Filter("status", "Pending")
+
 		centralPotUser, err := client.UserByEmail(ctx, sirius.PotUserEmail)
 		if err != nil {
 			return err
 		}
 
-		criteria := sirius.Criteria{}.Filter("status", "Pending").Page(getPage(r)).Sort("receiptDate", sirius.Ascending)
-		teamCases, pagination, err := client.CasesByAssignee(ctx, centralPotUser.ID, criteria)
+		if format != "" {
+			cases := client.AllCasesByAssignee(ctx, centralPotUser.ID, criteria)
+			return exportCases(w, format, "central-pot", cases, time.Now())
+		}
+
+		teamCases, pagination, err := client.CasesByAssignee(ctx, centralPotUser.ID, withPageSize(criteria.Page(getPage(r)), pageSize))
 
 		if err != nil {
 			return err
 		}
 
-		criteria = sirius.Criteria{}.Filter("status", "Pending").Sort("receiptDate", sirius.Ascending).Limit(1).Page(1)
-		oldestCases, _, err := client.CasesByAssignee(ctx, centralPotUser.ID, criteria)
+		oldestCriteria := criteria.Unsorted().Sort("receiptDate", sirius.Ascending).Limit(1).Page(1)
+		oldestCases, _, err := client.CasesByAssignee(ctx, centralPotUser.ID, oldestCriteria)
 
 		if err != nil {
 			return err
 		}
 
+		query := filters.Encode()
+
 		vars := centralCasesVars{
 			Cases:        teamCases,
-			Pagination:   newPagination(pagination),
+			Pagination:   newPaginationWithQuery(pagination, query),
 			IsCaseWorker: myDetails.HasRole("Self Allocation User"),
+			Sort:         newSort(centralCasesSchema, filters.Criteria(), criteria),
+			ExportQuery:  queryPrefix(query),
+			Filters:      filters,
 		}
 
 		if len(oldestCases) > 0 {
@@ -2,15 +2,26 @@ package server
 
 import (
 	"errors"
+	"iter"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"testing"
+	"time"
 
 	"github.com/ministryofjustice/opg-sirius-lpa-dashboard/internal/sirius"
 	"github.com/stretchr/testify/assert"
 )
 
 type mockCentralCasesClient struct {
+	allCasesByAssignee struct {
+		count        int
+		lastCtx      sirius.Context
+		lastId       int
+		lastCriteria sirius.Criteria
+		data         []sirius.Case
+		err          error
+	}
 	casesByAssignee struct {
 		count      int
 		lastCtx    sirius.Context
@@ -35,6 +46,15 @@ type mockCentralCasesClient struct {
 	}
 }
 
+func (m *mockCentralCasesClient) AllCasesByAssignee(ctx sirius.Context, id int, criteria sirius.Criteria) iter.Seq2[sirius.Case, error] {
+	m.allCasesByAssignee.count += 1
+	m.allCasesByAssignee.lastCtx = ctx
+	m.allCasesByAssignee.lastId = id
+	m.allCasesByAssignee.lastCriteria = criteria
+
+	return mockCaseSeq(m.allCasesByAssignee.data, m.allCasesByAssignee.err)
+}
+
 func (m *mockCentralCasesClient) CasesByAssignee(ctx sirius.Context, id int, criteria sirius.Criteria) ([]sirius.Case, *sirius.Pagination, error) {
 	m.casesByAssignee.count += 1
 	m.casesByAssignee.lastCtx = ctx
@@ -81,7 +101,7 @@ func TestGetCentralCases(t *testing.T) {
 	w := httptest.NewRecorder()
 	r, _ := http.NewRequest("GET", "/path", nil)
 
-	err := centralCases(client, template)(w, r)
+	err := centralCases(client, template, "")(w, r)
 	assert.Nil(err)
 
 	assert.Equal(1, client.myDetails.count)
@@ -100,9 +120,11 @@ func TestGetCentralCases(t *testing.T) {
 	assert.Equal(1, template.count)
 	assert.Equal("page", template.lastName)
 	assert.Equal(centralCasesVars{
-		Cases:    client.casesByAssignee.data,
-		TeamID:   123,
-		TeamName: "team",
+		Cases:       client.casesByAssignee.data,
+		TeamID:      123,
+		TeamName:    "team",
+		Sort:        Sort{Field: "receiptDate", Order: "asc"},
+		ExportQuery: "?",
 	}, template.lastVars)
 }
 
@@ -127,7 +149,7 @@ func TestGetCentralCasesPage(t *testing.T) {
 	w := httptest.NewRecorder()
 	r, _ := http.NewRequest("GET", "/path?page=4", nil)
 
-	err := centralCases(client, template)(w, r)
+	err := centralCases(client, template, "")(w, r)
 	assert.Nil(err)
 
 	assert.Equal(1, client.myDetails.count)
@@ -146,7 +168,9 @@ func TestGetCentralCasesPage(t *testing.T) {
 	assert.Equal(1, template.count)
 	assert.Equal("page", template.lastName)
 	assert.Equal(centralCasesVars{
-		Cases: client.casesByAssignee.data,
+		Cases:       client.casesByAssignee.data,
+		Sort:        Sort{Field: "receiptDate", Order: "asc"},
+		ExportQuery: "?",
 	}, template.lastVars)
 }
 
@@ -162,7 +186,7 @@ func TestGetCentralCasesForbidden(t *testing.T) {
 	w := httptest.NewRecorder()
 	r, _ := http.NewRequest("GET", "/path", nil)
 
-	err := centralCases(client, template)(w, r)
+	err := centralCases(client, template, "")(w, r)
 	assert.Equal(StatusError(http.StatusForbidden), err)
 
 	assert.Equal(1, client.myDetails.count)
@@ -184,7 +208,7 @@ func TestGetCentralCasesMyDetailsError(t *testing.T) {
 	w := httptest.NewRecorder()
 	r, _ := http.NewRequest("GET", "/path", nil)
 
-	err := centralCases(client, template)(w, r)
+	err := centralCases(client, template, "")(w, r)
 	assert.Equal(expectedError, err)
 
 	assert.Equal(1, client.myDetails.count)
@@ -209,7 +233,7 @@ func TestGetCentralCasesUserError(t *testing.T) {
 	w := httptest.NewRecorder()
 	r, _ := http.NewRequest("GET", "/path", nil)
 
-	err := centralCases(client, template)(w, r)
+	err := centralCases(client, template, "")(w, r)
 	assert.Equal(expectedError, err)
 
 	assert.Equal(1, client.myDetails.count)
@@ -240,7 +264,7 @@ func TestGetCentralCasesQueryError(t *testing.T) {
 	w := httptest.NewRecorder()
 	r, _ := http.NewRequest("GET", "/path", nil)
 
-	err := centralCases(client, template)(w, r)
+	err := centralCases(client, template, "")(w, r)
 	assert.Equal(expectedError, err)
 
 	assert.Equal(1, client.myDetails.count)
@@ -265,7 +289,7 @@ func TestBadMethodCentralCases(t *testing.T) {
 	w := httptest.NewRecorder()
 	r, _ := http.NewRequest("DELETE", "/path", nil)
 
-	err := centralCases(client, template)(w, r)
+	err := centralCases(client, template, "")(w, r)
 
 	assert.Equal(StatusError(http.StatusMethodNotAllowed), err)
 
@@ -273,3 +297,187 @@ func TestBadMethodCentralCases(t *testing.T) {
 	assert.Equal(0, client.casesByAssignee.count)
 	assert.Equal(0, template.count)
 }
+
+func TestGetCentralCasesExport(t *testing.T) {
+	assert := assert.New(t)
+
+	client := &mockCentralCasesClient{}
+	client.myDetails.data = sirius.MyDetails{
+		Roles: []string{"Manager"},
+	}
+	client.userByEmail.data = sirius.User{
+		ID: 14,
+	}
+	client.allCasesByAssignee.data = []sirius.Case{{
+		Uid:   "7000-0000-0001",
+		Donor: sirius.Donor{Firstname: "Adrian", Surname: "Kurkjian"},
+	}}
+	template := &mockTemplate{}
+
+	w := httptest.NewRecorder()
+	r, _ := http.NewRequest("GET", "/path?format=xlsx", nil)
+
+	err := centralCases(client, template, "")(w, r)
+	assert.Nil(err)
+
+	assert.Equal(0, client.casesByAssignee.count)
+	assert.Equal(1, client.allCasesByAssignee.count)
+	assert.Equal(getContext(r), client.allCasesByAssignee.lastCtx)
+	assert.Equal(14, client.allCasesByAssignee.lastId)
+	assert.Equal(sirius.Criteria{}.Filter("status", "Pending").Sort("receiptDate", sirius.Ascending), client.allCasesByAssignee.lastCriteria)
+
+	assert.Equal(0, template.count)
+
+	resp := w.Result()
+	assert.Equal("application/vnd.openxmlformats-officedocument.spreadsheetml.sheet", resp.Header.Get("Content-Type"))
+	assert.Equal(`attachment; filename="central-pot-`+time.Now().Format("2006-01-02")+`.xlsx"`, resp.Header.Get("Content-Disposition"))
+}
+
+func TestGetCentralCasesExportBadFormat(t *testing.T) {
+	assert := assert.New(t)
+
+	client := &mockCentralCasesClient{}
+	client.myDetails.data = sirius.MyDetails{
+		Roles: []string{"Manager"},
+	}
+
+	w := httptest.NewRecorder()
+	r, _ := http.NewRequest("GET", "/path?format=pdf", nil)
+
+	err := centralCases(client, nil, "")(w, r)
+	assert.Equal(StatusError(http.StatusBadRequest), err)
+
+	assert.Equal(0, client.userByEmail.count)
+	assert.Equal(0, client.allCasesByAssignee.count)
+}
+
+func TestGetCentralCasesFiltered(t *testing.T) {
+	assert := assert.New(t)
+
+	client := &mockCentralCasesClient{}
+	client.myDetails.data = sirius.MyDetails{
+		Roles: []string{"Manager"},
+	}
+	client.userByEmail.data = sirius.User{
+		ID: 14,
+	}
+	client.casesByAssignee.pagination = &sirius.Pagination{TotalPages: 1}
+	template := &mockTemplate{}
+
+	w := httptest.NewRecorder()
+	r, _ := http.NewRequest("GET", "/path?surname-initial=K&date-from=2021-01-02&sort=uId:desc", nil)
+
+	err := centralCases(client, template, "")(w, r)
+	assert.Nil(err)
+
+	assert.Equal(2, client.casesByAssignee.count)
+	assert.Equal(sirius.Criteria{}.
+		Filter("date-from", "2021-01-02").
+		Filter("surname-initial", "K").
+		Sort("uId", sirius.Descending).
+		Filter("status", "Pending").
+		Page(1), client.casesByAssignee.criteria[0])
+	assert.Equal(sirius.Criteria{}.
+		Filter("date-from", "2021-01-02").
+		Filter("surname-initial", "K").
+		Filter("status", "Pending").
+		Sort("receiptDate", sirius.Ascending).
+		Limit(1).
+		Page(1), client.casesByAssignee.criteria[1])
+
+	vars := template.lastVars.(centralCasesVars)
+	assert.Equal("?date-from=2021-01-02&sort=uId%3Adesc&surname-initial=K&", vars.ExportQuery)
+	assert.Equal(vars.ExportQuery, vars.Pagination.Query)
+	assert.Equal(centralCasesFilters{
+		Set:            true,
+		DateFrom:       time.Date(2021, time.January, 2, 0, 0, 0, 0, time.UTC),
+		SurnameInitial: "K",
+		criteria: sirius.Criteria{}.
+			Filter("date-from", "2021-01-02").
+			Filter("surname-initial", "K").
+			Sort("uId", sirius.Descending),
+	}, vars.Filters)
+}
+
+func TestGetCentralCasesBadFilter(t *testing.T) {
+	assert := assert.New(t)
+
+	client := &mockCentralCasesClient{}
+	client.myDetails.data = sirius.MyDetails{
+		Roles: []string{"Manager"},
+	}
+
+	w := httptest.NewRecorder()
+	r, _ := http.NewRequest("GET", "/path?caseSubtype=what", nil)
+
+	err := centralCases(client, nil, "")(w, r)
+	assert.IsType(sirius.CriteriaError{}, err)
+
+	assert.Equal(0, client.userByEmail.count)
+	assert.Equal(0, client.casesByAssignee.count)
+}
+
+func TestCentralCasesFilters(t *testing.T) {
+	testCases := map[string]struct {
+		Input    string
+		Encoded  string
+		Criteria sirius.Criteria
+	}{
+		"empty": {
+			Input:    "",
+			Encoded:  "",
+			Criteria: sirius.Criteria{},
+		},
+		"all": {
+			Input:   "caseSubtype=pfa&caseSubtype=hw&date-from=2021-01-02&date-to=2021-01-03&lpa-type=both&surname-initial=M",
+			Encoded: "caseSubtype=pfa&caseSubtype=hw&date-from=2021-01-02&date-to=2021-01-03&lpa-type=both&surname-initial=M",
+			Criteria: sirius.Criteria{}.
+				Filter("date-from", "2021-01-02").
+				Filter("date-to", "2021-01-03").
+				Filter("lpa-type", "both").
+				Filter("caseSubtype", "pfa").
+				Filter("caseSubtype", "hw").
+				Filter("surname-initial", "M"),
+		},
+		"empty values": {
+			Input:    "date-from=&date-to=&surname-initial=",
+			Encoded:  "",
+			Criteria: sirius.Criteria{},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			query, _ := url.ParseQuery(tc.Input)
+			filters, err := newCentralCasesFilters(query)
+
+			assert.Nil(t, err)
+			assert.Equal(t, tc.Encoded, filters.Encode())
+			assert.Equal(t, tc.Criteria, filters.Criteria())
+		})
+	}
+}
+
+func TestCentralCasesFiltersInvalid(t *testing.T) {
+	testCases := map[string]struct {
+		Input string
+		Field string
+	}{
+		"date-range-bad":      {Input: "date-to=what", Field: "date-to"},
+		"lpa-type-unknown":    {Input: "lpa-type=what", Field: "lpa-type"},
+		"subtype-unknown":     {Input: "caseSubtype=what", Field: "caseSubtype"},
+		"surname-initial-bad": {Input: "surname-initial=AB", Field: "surname-initial"},
+		"surname-initial-two": {Input: "surname-initial=A&surname-initial=B", Field: "surname-initial"},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			query, _ := url.ParseQuery(tc.Input)
+			_, err := newCentralCasesFilters(query)
+
+			criteriaError, ok := err.(sirius.CriteriaError)
+			assert.True(t, ok)
+			assert.Equal(t, tc.Field, criteriaError[0].Field)
+		})
+	}
+}
@@ -0,0 +1,152 @@
+package server
+
+import (
+	"encoding/csv"
+	"fmt"
+	"iter"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/ministryofjustice/opg-sirius-lpa-dashboard/internal/sirius"
+)
+
+const (
+	exportCSV  = "csv"
+	exportXLSX = "xlsx"
+)
+
+var caseExportHeader = []any{"Donor", "Case UID", "LPA type", "Received", "Status", "Worked", "Allocation", "Age (days)"}
+
+func getExportFormat(r *http.Request) (string, error) {
+	switch format := r.FormValue("format"); format {
+	case "", exportCSV, exportXLSX:
+		return format, nil
+	default:
+		return "", StatusError(http.StatusBadRequest)
+	}
+}
+
+// Nothing is written until the first page has been read, so an error there
+// still shows the error page. Later errors abort the response rather than
+// leave a truncated file.
+func exportCases(w http.ResponseWriter, format, name string, cases iter.Seq2[sirius.Case, error], today time.Time) error {
+	var rows rowWriter
+
+	for c, err := range cases {
+		if err != nil {
+			if rows == nil {
+				return err
+			}
+
+			panic(http.ErrAbortHandler)
+		}
+
+		if rows == nil {
+			if rows, err = startExport(w, format, name, today); err != nil {
+				return err
+			}
+		}
+
+		if err := rows.Write(caseExportRow(c, today)); err != nil {
+			return err
+		}
+	}
+
+	if rows == nil {
+		var err error
+		if rows, err = startExport(w, format, name, today); err != nil {
+			return err
+		}
+	}
+
+	return rows.Close()
+}
+
+func startExport(w http.ResponseWriter, format, name string, today time.Time) (rowWriter, error) {
+	filename := fmt.Sprintf("%s-%s.%s", name, today.Format("2006-01-02"), format)
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, filename))
+
+	var rows rowWriter
+	if format == exportXLSX {
+		w.Header().Set("Content-Type", "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet")
+
+		var err error
+		if rows, err = newXLSXWriter(w, "Cases"); err != nil {
+			return nil, err
+		}
+	} else {
+		w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+		rows = &csvWriter{w: csv.NewWriter(w)}
+	}
+
+	return rows, rows.Write(caseExportHeader)
+}
+
+type rowWriter interface {
+	Write(row []any) error
+	Close() error
+}
+
+type csvWriter struct {
+	w *csv.Writer
+}
+
+func (c *csvWriter) Write(row []any) error {
+	record := make([]string, len(row))
+	for i, cell := range row {
+		if s, ok := cell.(string); ok {
+			record[i] = spreadsheetText(s)
+		} else {
+			record[i] = fmt.Sprint(cell)
+		}
+	}
+
+	return c.w.Write(record)
+}
+
+func (c *csvWriter) Close() error {
+	c.w.Flush()
+	return c.w.Error()
+}
+
+// spreadsheetText stops CSV text that looks like a formula being run as one.
+// Inline strings in XLSX are never run, so do not need it.
+func spreadsheetText(s string) string {
+	if s != "" && strings.ContainsRune("=+-@\t\r", rune(s[0])) {
+		return "'" + s
+	}
+
+	return s
+}
+
+func caseExportRow(c sirius.Case, today time.Time) []any {
+	worked := "No"
+	if c.Worked {
+		worked = "Yes"
+	}
+
+	var received, age any = "", ""
+	if !c.ReceiptDate.IsZero() {
+		received = c.ReceiptDate.Format("2006-01-02")
+		age = daysBetween(c.ReceiptDate.Time, today)
+	}
+
+	return []any{
+		c.Donor.DisplayName(),
+		c.Uid,
+		strings.ToUpper(c.SubType),
+		received,
+		c.Status,
+		worked,
+		c.Assignee.DisplayName,
+		age,
+	}
+}
+
+func daysBetween(from, to time.Time) int {
+	fy, fm, fd := from.Date()
+	ty, tm, td := to.Date()
+
+	return int(time.Date(ty, tm, td, 0, 0, 0, 0, time.UTC).Sub(time.Date(fy, fm, fd, 0, 0, 0, 0, time.UTC)).Hours() / 24)
+}
@@ -0,0 +1,159 @@
+package server
+
+import (
+	"errors"
+	"iter"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ministryofjustice/opg-sirius-lpa-dashboard/internal/sirius"
+	"github.com/stretchr/testify/assert"
+)
+
+func mockCaseSeq(cases []sirius.Case, err error) iter.Seq2[sirius.Case, error] {
+	return func(yield func(sirius.Case, error) bool) {
+		for _, c := range cases {
+			if !yield(c, nil) {
+				return
+			}
+		}
+
+		if err != nil {
+			yield(sirius.Case{}, err)
+		}
+	}
+}
+
+func TestGetExportFormat(t *testing.T) {
+	for query, expected := range map[string]string{
+		"":             "",
+		"?format=csv":  exportCSV,
+		"?format=xlsx": exportXLSX,
+	} {
+		t.Run(query, func(t *testing.T) {
+			r, _ := http.NewRequest("GET", "/path"+query, nil)
+
+			format, err := getExportFormat(r)
+			assert.Nil(t, err)
+			assert.Equal(t, expected, format)
+		})
+	}
+}
+
+func TestGetExportFormatUnknown(t *testing.T) {
+	r, _ := http.NewRequest("GET", "/path?format=pdf", nil)
+
+	_, err := getExportFormat(r)
+	assert.Equal(t, StatusError(http.StatusBadRequest), err)
+}
+
+func TestExportCasesCSV(t *testing.T) {
+	assert := assert.New(t)
+
+	today := time.Date(2022, time.March, 10, 15, 0, 0, 0, time.UTC)
+	cases := []sirius.Case{{
+		Uid:         "7000-0000-0001",
+		Donor:       sirius.Donor{Firstname: "Adrian", Surname: "Kurkjian"},
+		SubType:     "pfa",
+		ReceiptDate: sirius.SiriusDate{Time: time.Date(2022, time.March, 1, 0, 0, 0, 0, time.UTC)},
+		Status:      "Pending",
+		Worked:      true,
+		Assignee:    sirius.Assignee{DisplayName: "Carline Elder"},
+	}, {
+		Uid:     "7000-0000-0002",
+		Donor:   sirius.Donor{Firstname: "Wilma", Surname: "Ruthman, Jr."},
+		SubType: "hw",
+		Status:  "Pending",
+	}}
+
+	w := httptest.NewRecorder()
+
+	err := exportCases(w, exportCSV, "cases", mockCaseSeq(cases, nil), today)
+	assert.Nil(err)
+
+	assert.Equal("text/csv; charset=utf-8", w.Result().Header.Get("Content-Type"))
+	assert.Equal(`attachment; filename="cases-2022-03-10.csv"`, w.Result().Header.Get("Content-Disposition"))
+	assert.Equal(`Donor,Case UID,LPA type,Received,Status,Worked,Allocation,Age (days)
+Adrian Kurkjian,7000-0000-0001,PFA,2022-03-01,Pending,Yes,Carline Elder,9
+"Wilma Ruthman, Jr.",7000-0000-0002,HW,,Pending,No,,
+`, w.Body.String())
+}
+
+func TestExportCasesFormula(t *testing.T) {
+	assert := assert.New(t)
+
+	cases := []sirius.Case{{
+		Uid:   "7000-0000-0001",
+		Donor: sirius.Donor{Firstname: "Adrian", Surname: `=HYPERLINK("http://example.com","Kurkjian")`},
+	}, {
+		Uid:   "7000-0000-0002",
+		Donor: sirius.Donor{Firstname: "@Wilma", Surname: "Ruthman"},
+	}}
+
+	w := httptest.NewRecorder()
+
+	err := exportCases(w, exportCSV, "cases", mockCaseSeq(cases, nil), time.Now())
+	assert.Nil(err)
+
+	assert.Contains(w.Body.String(), "\n\"Adrian =HYPERLINK(\"\"http://example.com\"\",\"\"Kurkjian\"\")\",7000-0000-0001,")
+	assert.Contains(w.Body.String(), "\n'@Wilma Ruthman,7000-0000-0002,")
+}
+
+func TestSpreadsheetText(t *testing.T) {
+	for in, out := range map[string]string{
+		"":          "",
+		"Adrian":    "Adrian",
+		"=1+1":      "'=1+1",
+		"+1":        "'+1",
+		"-1":        "'-1",
+		"@SUM(A1)":  "'@SUM(A1)",
+		"\tTab":     "'\tTab",
+		"\rReturn":  "'\rReturn",
+		"2022-03-1": "2022-03-1",
+	} {
+		assert.Equal(t, out, spreadsheetText(in))
+	}
+}
+
+func TestExportCasesError(t *testing.T) {
+	assert := assert.New(t)
+
+	expectedError := errors.New("oops")
+	w := httptest.NewRecorder()
+
+	err := exportCases(w, exportCSV, "cases", mockCaseSeq(nil, expectedError), time.Now())
+	assert.Equal(expectedError, err)
+
+	assert.Equal("", w.Result().Header.Get("Content-Disposition"))
+	assert.Equal("", w.Body.String())
+}
+
+func TestExportCasesErrorAfterStarting(t *testing.T) {
+	w := httptest.NewRecorder()
+
+	assert.PanicsWithValue(t, http.ErrAbortHandler, func() {
+		_ = exportCases(w, exportCSV, "cases", mockCaseSeq([]sirius.Case{{}}, errors.New("oops")), time.Now())
+	})
+}
+
+func TestExportCasesNone(t *testing.T) {
+	assert := assert.New(t)
+
+	w := httptest.NewRecorder()
+
+	err := exportCases(w, exportXLSX, "cases", mockCaseSeq(nil, nil), time.Now())
+	assert.Nil(err)
+
+	assert.Equal("application/vnd.openxmlformats-officedocument.spreadsheetml.sheet", w.Result().Header.Get("Content-Type"))
+	assert.NotEmpty(w.Body.Bytes())
+}
+
+func TestDaysBetween(t *testing.T) {
+	london, _ := time.LoadLocation("Europe/London")
+
+	assert.Equal(t, 0, daysBetween(time.Date(2022, time.March, 1, 0, 0, 0, 0, time.UTC), time.Date(2022, time.March, 1, 23, 0, 0, 0, time.UTC)))
+	assert.Equal(t, 1, daysBetween(time.Date(2022, time.March, 1, 23, 0, 0, 0, time.UTC), time.Date(2022, time.March, 2, 1, 0, 0, 0, time.UTC)))
+	assert.Equal(t, 31, daysBetween(time.Date(2022, time.March, 1, 0, 0, 0, 0, time.UTC), time.Date(2022, time.April, 1, 9, 0, 0, 0, london)))
+}
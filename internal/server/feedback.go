@@ -1,21 +1,29 @@
 package server
 
 import (
+	"errors"
 	"net/http"
+	"strconv"
+	"strings"
 
 	"github.com/ministryofjustice/opg-sirius-lpa-dashboard/internal/sirius"
 )
 
 type FeedbackClient interface {
-	Feedback(sirius.Context, string) error
+	Feedback(sirius.Context, sirius.Feedback) error
+	MyDetails(sirius.Context) (sirius.MyDetails, error)
 }
 
 type feedbackVars struct {
-	XSRFToken string
-	Redirect  string
+	XSRFToken string `json:"-"`
+	Redirect  string `json:"redirect"`
+	Feedback  string `json:"feedback"`
+	Rating    int    `json:"rating"`
+	Error     string `json:"error"`
+	Queued    bool   `json:"queued"`
 }
 
-func feedback(client FeedbackClient, tmpl Template) Handler {
+func feedback(client FeedbackClient, tmpl Template, outbox *FeedbackOutbox) Handler {
 	return func(w http.ResponseWriter, r *http.Request) error {
 		ctx := getContext(r)
 
@@ -27,11 +35,65 @@ func feedback(client FeedbackClient, tmpl Template) Handler {
 			})
 
 		case http.MethodPost:
-			if err := client.Feedback(ctx, r.FormValue("feedback")); err != nil {
+			vars := feedbackVars{
+				XSRFToken: ctx.XSRFToken,
+				Redirect:  r.FormValue("redirect"),
+				Feedback:  r.FormValue("feedback"),
+			}
+
+			if v := r.FormValue("rating"); v != "" {
+				rating, err := strconv.Atoi(v)
+				if err != nil || rating < 1 || rating > 5 {
+					return StatusError(http.StatusBadRequest)
+				}
+				vars.Rating = rating
+			}
+
+			if strings.TrimSpace(vars.Feedback) == "" {
+				vars.Error = "Enter your feedback"
+				return tmpl.ExecuteTemplate(w, "page", vars)
+			}
+
+			// The feedback is still worth sending if the user's details
+			// cannot be found, it will just be missing who sent it.
+			myDetails, err := client.MyDetails(ctx)
+			if errors.Is(err, sirius.ErrUnauthorized) {
 				return err
 			}
 
-			return RedirectError(r.FormValue("redirect"))
+			message := sirius.Feedback{
+				Message: vars.Feedback,
+				Page:    vars.Redirect,
+				Roles:   myDetails.Roles,
+				Rating:  vars.Rating,
+			}
+			if len(myDetails.Teams) > 0 {
+				message.Team = myDetails.Teams[0].DisplayName
+			}
+
+			err = client.Feedback(ctx, message)
+			if err == nil {
+				return RedirectError(vars.Redirect)
+			}
+
+			if errors.Is(err, sirius.ErrUnauthorized) {
+				return err
+			}
+
+			switch {
+			case !isTransient(err):
+				vars.Error = problemReason(err, "Sirius could not accept your feedback")
+			case outbox == nil || outbox.Add(message) != nil:
+				vars.Error = "Your feedback could not be sent, try again later"
+			default:
+				// The form is not shown again, so that the same feedback is
+				// not sent twice.
+				vars.Queued = true
+				vars.Feedback = ""
+				vars.Rating = 0
+			}
+
+			return tmpl.ExecuteTemplate(w, "page", vars)
 
 		default:
 			return StatusError(http.StatusMethodNotAllowed)
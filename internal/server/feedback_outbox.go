@@ -0,0 +1,164 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/ministryofjustice/opg-sirius-lpa-dashboard/internal/jsonfile"
+	"github.com/ministryofjustice/opg-sirius-lpa-dashboard/internal/sirius"
+)
+
+const (
+	feedbackOutboxSize        = 100
+	feedbackOutboxMaxAttempts = 10
+	feedbackOutboxInterval    = time.Minute
+)
+
+type feedbackSender interface {
+	Feedback(sirius.Context, sirius.Feedback) error
+}
+
+type feedbackOutboxItem struct {
+	Feedback sirius.Feedback `json:"feedback"`
+	Attempts int             `json:"attempts"`
+}
+
+// FeedbackOutbox resends feedback with the dashboard's own token, as the
+// user's session may have ended by then.
+type FeedbackOutbox struct {
+	client   feedbackSender
+	logger   *slog.Logger
+	token    string
+	path     string
+	interval time.Duration
+
+	mu      sync.Mutex
+	items   []feedbackOutboxItem
+	running bool
+}
+
+func NewFeedbackOutbox(client feedbackSender, logger *slog.Logger, token, path string) (*FeedbackOutbox, error) {
+	o := &FeedbackOutbox{
+		client:   client,
+		logger:   logger,
+		token:    token,
+		path:     path,
+		interval: feedbackOutboxInterval,
+	}
+
+	if path != "" {
+		if err := jsonfile.Read(path, &o.items); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(o.items) > 0 {
+		o.running = true
+		go o.run()
+	}
+
+	return o, nil
+}
+
+func (o *FeedbackOutbox) Add(feedback sirius.Feedback) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	items := o.items
+	if len(items) >= feedbackOutboxSize {
+		o.logger.Warn("feedback outbox full, dropping oldest feedback", slog.String("page", items[0].Feedback.Page))
+		items = items[1:]
+	}
+
+	items = append(items, feedbackOutboxItem{Feedback: feedback})
+	if err := o.write(items); err != nil {
+		return err
+	}
+
+	o.items = items
+
+	if !o.running {
+		o.running = true
+		go o.run()
+	}
+
+	return nil
+}
+
+func (o *FeedbackOutbox) run() {
+	for {
+		time.Sleep(o.interval)
+
+		if o.flush() == 0 {
+			return
+		}
+	}
+}
+
+// flush returns how many items are still waiting.
+func (o *FeedbackOutbox) flush() int {
+	o.mu.Lock()
+	items := o.items
+	o.items = nil
+	o.mu.Unlock()
+
+	ctx := sirius.Context{Context: context.Background(), Token: o.token}
+
+	var waiting []feedbackOutboxItem
+	for _, item := range items {
+		err := o.client.Feedback(ctx, item.Feedback)
+		if err == nil {
+			continue
+		}
+
+		item.Attempts++
+		if !isTransient(err) || item.Attempts >= feedbackOutboxMaxAttempts {
+			o.logger.Error("giving up sending feedback", slog.String("page", item.Feedback.Page), slog.Int("attempts", item.Attempts), slog.Any("err", err.Error()))
+			continue
+		}
+
+		waiting = append(waiting, item)
+	}
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	o.items = append(waiting, o.items...)
+	if err := o.write(o.items); err != nil {
+		o.logger.Error("could not save feedback outbox", slog.Any("err", err.Error()))
+	}
+
+	if len(o.items) == 0 {
+		o.running = false
+	}
+
+	return len(o.items)
+}
+
+// write must be called with mu held.
+func (o *FeedbackOutbox) write(items []feedbackOutboxItem) error {
+	if o.path == "" {
+		return nil
+	}
+
+	return jsonfile.Write(o.path, items)
+}
+
+// Sirius rejecting the request, or the session ending, will not change by
+// waiting.
+func isTransient(err error) bool {
+	if errors.Is(err, sirius.ErrUnauthorized) {
+		return false
+	}
+
+	var statusError *sirius.StatusError
+	if errors.As(err, &statusError) {
+		return statusError.Code >= http.StatusInternalServerError
+	}
+
+	return true
+}
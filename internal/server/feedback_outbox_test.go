@@ -0,0 +1,145 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ministryofjustice/opg-sirius-lpa-dashboard/internal/sirius"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockFeedbackSender struct {
+	sent    []sirius.Feedback
+	errs    []error
+	lastCtx sirius.Context
+}
+
+func (m *mockFeedbackSender) Feedback(ctx sirius.Context, feedback sirius.Feedback) error {
+	m.lastCtx = ctx
+
+	if len(m.errs) > 0 {
+		err := m.errs[0]
+		m.errs = m.errs[1:]
+		if err != nil {
+			return err
+		}
+	}
+
+	m.sent = append(m.sent, feedback)
+	return nil
+}
+
+func TestFeedbackOutboxFlush(t *testing.T) {
+	assert := assert.New(t)
+
+	sender := &mockFeedbackSender{errs: []error{errors.New("connection refused")}}
+	outbox := testFeedbackOutbox(sender)
+
+	outbox.Add(sirius.Feedback{Message: "a"})
+
+	assert.Equal(1, outbox.flush())
+	assert.Empty(sender.sent)
+
+	assert.Equal(0, outbox.flush())
+	assert.Equal([]sirius.Feedback{{Message: "a"}}, sender.sent)
+	assert.False(outbox.running)
+}
+
+func TestFeedbackOutboxGivesUp(t *testing.T) {
+	testCases := map[string][]error{
+		"rejected":     {&sirius.StatusError{Code: http.StatusBadRequest}},
+		"unauthorized": {sirius.ErrUnauthorized},
+		"attempts":     make([]error, feedbackOutboxMaxAttempts),
+	}
+	for i := range testCases["attempts"] {
+		testCases["attempts"][i] = &sirius.StatusError{Code: http.StatusBadGateway}
+	}
+
+	for name, errs := range testCases {
+		t.Run(name, func(t *testing.T) {
+			sender := &mockFeedbackSender{errs: errs}
+			outbox := testFeedbackOutbox(sender)
+
+			outbox.Add(sirius.Feedback{Message: "a"})
+
+			for range errs {
+				outbox.flush()
+			}
+
+			assert.Empty(t, outbox.items)
+			assert.Empty(t, sender.sent)
+		})
+	}
+}
+
+func TestFeedbackOutboxFull(t *testing.T) {
+	assert := assert.New(t)
+
+	outbox := testFeedbackOutbox(&mockFeedbackSender{})
+
+	for i := 0; i <= feedbackOutboxSize; i++ {
+		outbox.Add(sirius.Feedback{Rating: i})
+	}
+
+	outbox.mu.Lock()
+	defer outbox.mu.Unlock()
+	assert.Len(outbox.items, feedbackOutboxSize)
+	assert.Equal(1, outbox.items[0].Feedback.Rating)
+}
+
+func TestFeedbackOutboxUsesToken(t *testing.T) {
+	assert := assert.New(t)
+
+	sender := &mockFeedbackSender{}
+	outbox := testFeedbackOutbox(sender)
+
+	assert.Nil(outbox.Add(sirius.Feedback{Message: "a"}))
+	outbox.flush()
+
+	assert.Equal(sirius.Context{Context: context.Background(), Token: "service-token"}, sender.lastCtx)
+}
+
+func TestFeedbackOutboxFile(t *testing.T) {
+	assert := assert.New(t)
+
+	path := filepath.Join(t.TempDir(), "outbox.json")
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	outbox, err := NewFeedbackOutbox(&mockFeedbackSender{}, logger, "service-token", path)
+	assert.Nil(err)
+	outbox.interval = time.Hour
+
+	assert.Nil(outbox.Add(sirius.Feedback{Message: "a", Rating: 2}))
+
+	data, _ := os.ReadFile(path)
+	assert.JSONEq(`[{"feedback":{"Message":"a","Page":"","Roles":null,"Team":"","Rating":2},"attempts":0}]`, string(data))
+
+	sender := &mockFeedbackSender{}
+	restarted, err := NewFeedbackOutbox(sender, logger, "service-token", path)
+	assert.Nil(err)
+
+	restarted.mu.Lock()
+	assert.Equal([]feedbackOutboxItem{{Feedback: sirius.Feedback{Message: "a", Rating: 2}}}, restarted.items)
+	restarted.mu.Unlock()
+
+	assert.Equal(0, restarted.flush())
+	assert.Equal([]sirius.Feedback{{Message: "a", Rating: 2}}, sender.sent)
+
+	data, _ = os.ReadFile(path)
+	assert.JSONEq(`null`, string(data))
+}
+
+func TestFeedbackOutboxFileInvalid(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "outbox.json")
+	_ = os.WriteFile(path, []byte("not json"), 0o600)
+
+	_, err := NewFeedbackOutbox(&mockFeedbackSender{}, slog.New(slog.NewTextHandler(io.Discard, nil)), "service-token", path)
+	assert.NotNil(t, err)
+}
@@ -2,10 +2,13 @@ package server
 
 import (
 	"errors"
+	"io"
+	"log/slog"
 	"net/http"
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/ministryofjustice/opg-sirius-lpa-dashboard/internal/sirius"
 	"github.com/stretchr/testify/assert"
@@ -13,21 +16,46 @@ import (
 
 type mockFeedbackClient struct {
 	feedback struct {
-		count       int
-		lastCtx     sirius.Context
-		lastMessage string
-		err         error
+		count    int
+		lastCtx  sirius.Context
+		lastData sirius.Feedback
+		err      error
+	}
+	myDetails struct {
+		count   int
+		lastCtx sirius.Context
+		data    sirius.MyDetails
+		err     error
 	}
 }
 
-func (m *mockFeedbackClient) Feedback(ctx sirius.Context, message string) error {
+func (m *mockFeedbackClient) Feedback(ctx sirius.Context, feedback sirius.Feedback) error {
 	m.feedback.count += 1
 	m.feedback.lastCtx = ctx
-	m.feedback.lastMessage = message
+	m.feedback.lastData = feedback
 
 	return m.feedback.err
 }
 
+func (m *mockFeedbackClient) MyDetails(ctx sirius.Context) (sirius.MyDetails, error) {
+	m.myDetails.count += 1
+	m.myDetails.lastCtx = ctx
+
+	return m.myDetails.data, m.myDetails.err
+}
+
+func testFeedbackOutbox(client feedbackSender) *FeedbackOutbox {
+	outbox, _ := NewFeedbackOutbox(client, slog.New(slog.NewTextHandler(io.Discard, nil)), "service-token", "")
+	outbox.interval = time.Hour
+	return outbox
+}
+
+func postFeedbackRequest(body string) *http.Request {
+	r, _ := http.NewRequest("POST", "/path", strings.NewReader(body))
+	r.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+	return r
+}
+
 func TestGetFeedback(t *testing.T) {
 	assert := assert.New(t)
 
@@ -37,7 +65,7 @@ func TestGetFeedback(t *testing.T) {
 	r, _ := http.NewRequest("GET", "/path", nil)
 	r.Header.Add("Referer", "http://example.com/previous")
 
-	err := feedback(nil, template)(w, r)
+	err := feedback(nil, template, nil)(w, r)
 	assert.Nil(err)
 
 	assert.Equal(1, template.count)
@@ -52,37 +80,161 @@ func TestPostFeedback(t *testing.T) {
 	assert := assert.New(t)
 
 	client := &mockFeedbackClient{}
+	client.myDetails.data = sirius.MyDetails{
+		Roles: []string{"Manager"},
+		Teams: []sirius.MyDetailsTeam{{DisplayName: "Casework Team 1"}},
+	}
 
 	w := httptest.NewRecorder()
-	r, _ := http.NewRequest("POST", "/path", strings.NewReader("redirect=a&feedback=b"))
-	r.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+	r := postFeedbackRequest("redirect=a&feedback=b&rating=4")
 
-	err := feedback(client, nil)(w, r)
+	err := feedback(client, nil, nil)(w, r)
 	assert.Equal(RedirectError("a"), err)
 
 	assert.Equal(1, client.feedback.count)
 	assert.Equal(getContext(r), client.feedback.lastCtx)
-	assert.Equal("b", client.feedback.lastMessage)
+	assert.Equal(sirius.Feedback{
+		Message: "b",
+		Page:    "a",
+		Roles:   []string{"Manager"},
+		Team:    "Casework Team 1",
+		Rating:  4,
+	}, client.feedback.lastData)
 }
 
-func TestPostFeedbackError(t *testing.T) {
+func TestPostFeedbackMyDetailsError(t *testing.T) {
 	assert := assert.New(t)
 
-	expectedError := errors.New("oops")
+	client := &mockFeedbackClient{}
+	client.myDetails.err = errors.New("oops")
+
+	w := httptest.NewRecorder()
+	r := postFeedbackRequest("redirect=a&feedback=b")
+
+	err := feedback(client, nil, nil)(w, r)
+	assert.Equal(RedirectError("a"), err)
+
+	assert.Equal(sirius.Feedback{Message: "b", Page: "a"}, client.feedback.lastData)
+}
+
+func TestPostFeedbackUnauthorized(t *testing.T) {
+	assert := assert.New(t)
 
 	client := &mockFeedbackClient{}
-	client.feedback.err = expectedError
+	client.feedback.err = sirius.ErrUnauthorized
 
 	w := httptest.NewRecorder()
-	r, _ := http.NewRequest("POST", "/path", strings.NewReader("redirect=a&feedback=b"))
-	r.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+	r := postFeedbackRequest("redirect=a&feedback=b")
 
-	err := feedback(client, nil)(w, r)
-	assert.Equal(expectedError, err)
+	err := feedback(client, nil, nil)(w, r)
+	assert.Equal(sirius.ErrUnauthorized, err)
+}
 
-	assert.Equal(1, client.feedback.count)
-	assert.Equal(getContext(r), client.feedback.lastCtx)
-	assert.Equal("b", client.feedback.lastMessage)
+func TestPostFeedbackEmpty(t *testing.T) {
+	assert := assert.New(t)
+
+	client := &mockFeedbackClient{}
+	template := &mockTemplate{}
+
+	w := httptest.NewRecorder()
+	r := postFeedbackRequest("redirect=a&feedback=+&rating=2")
+
+	err := feedback(client, template, nil)(w, r)
+	assert.Nil(err)
+
+	assert.Equal(0, client.feedback.count)
+	assert.Equal(feedbackVars{
+		Redirect: "a",
+		Feedback: " ",
+		Rating:   2,
+		Error:    "Enter your feedback",
+	}, template.lastVars)
+}
+
+func TestPostFeedbackBadRating(t *testing.T) {
+	for _, rating := range []string{"0", "6", "good"} {
+		t.Run(rating, func(t *testing.T) {
+			client := &mockFeedbackClient{}
+
+			w := httptest.NewRecorder()
+			r := postFeedbackRequest("redirect=a&feedback=b&rating=" + rating)
+
+			err := feedback(client, nil, nil)(w, r)
+			assert.Equal(t, StatusError(http.StatusBadRequest), err)
+			assert.Equal(t, 0, client.feedback.count)
+		})
+	}
+}
+
+func TestPostFeedbackRejected(t *testing.T) {
+	assert := assert.New(t)
+
+	client := &mockFeedbackClient{}
+	client.feedback.err = &sirius.StatusError{
+		Code:    http.StatusBadRequest,
+		Problem: &sirius.Problem{Title: "Bad Request", Detail: "Message is too long", ValidationErrors: map[string]map[string]string{"message": {"stringLengthTooLong": "Message is too long"}}},
+	}
+	template := &mockTemplate{}
+	outbox := testFeedbackOutbox(client)
+
+	w := httptest.NewRecorder()
+	r := postFeedbackRequest("redirect=a&feedback=b")
+
+	err := feedback(client, template, outbox)(w, r)
+	assert.Nil(err)
+
+	assert.Equal(feedbackVars{
+		Redirect: "a",
+		Feedback: "b",
+		Error:    "Bad Request: message is too long",
+	}, template.lastVars)
+	assert.Len(outbox.items, 0)
+}
+
+func TestPostFeedbackQueued(t *testing.T) {
+	assert := assert.New(t)
+
+	client := &mockFeedbackClient{}
+	client.feedback.err = errors.New("connection refused")
+	template := &mockTemplate{}
+	outbox := testFeedbackOutbox(client)
+
+	w := httptest.NewRecorder()
+	r := postFeedbackRequest("redirect=a&feedback=b&rating=3")
+
+	err := feedback(client, template, outbox)(w, r)
+	assert.Nil(err)
+
+	assert.Equal(feedbackVars{
+		Redirect: "a",
+		Queued:   true,
+	}, template.lastVars)
+
+	outbox.mu.Lock()
+	defer outbox.mu.Unlock()
+	if assert.Len(outbox.items, 1) {
+		assert.Equal(sirius.Feedback{Message: "b", Page: "a", Rating: 3}, outbox.items[0].Feedback)
+	}
+}
+
+func TestPostFeedbackNoOutbox(t *testing.T) {
+	assert := assert.New(t)
+
+	client := &mockFeedbackClient{}
+	client.feedback.err = errors.New("connection refused")
+	template := &mockTemplate{}
+
+	w := httptest.NewRecorder()
+	r := postFeedbackRequest("redirect=a&feedback=b")
+
+	err := feedback(client, template, nil)(w, r)
+	assert.Nil(err)
+
+	assert.Equal(feedbackVars{
+		Redirect: "a",
+		Feedback: "b",
+		Error:    "Your feedback could not be sent, try again later",
+	}, template.lastVars)
 }
 
 func TestBadMethodFeedback(t *testing.T) {
@@ -91,6 +243,6 @@ func TestBadMethodFeedback(t *testing.T) {
 	w := httptest.NewRecorder()
 	r, _ := http.NewRequest("DELETE", "/path", nil)
 
-	err := feedback(nil, nil)(w, r)
+	err := feedback(nil, nil, nil)(w, r)
 	assert.Equal(StatusError(http.StatusMethodNotAllowed), err)
 }
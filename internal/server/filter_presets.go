@@ -0,0 +1,153 @@
+package server
+
+import (
+	"context"
+	"crypto/subtle"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/ministryofjustice/opg-sirius-lpa-dashboard/internal/presets"
+	"github.com/ministryofjustice/opg-sirius-lpa-dashboard/internal/sirius"
+)
+
+const maxPresetNameLength = 50
+
+// PresetStore keeps each user's saved filters for team work in progress.
+type PresetStore interface {
+	List(ctx context.Context, userID int) ([]presets.Preset, error)
+	Save(ctx context.Context, userID int, preset presets.Preset) error
+	Delete(ctx context.Context, userID int, name string) error
+}
+
+type FilterPresetsClient interface {
+	MyDetails(sirius.Context) (sirius.MyDetails, error)
+}
+
+type presetLink struct {
+	Name    string `json:"name"`
+	Query   string `json:"query"`
+	Default bool   `json:"default"`
+	Active  bool   `json:"active"`
+	Href    string `json:"-"`
+}
+
+func newPresetLinks(list []presets.Preset, current string) []presetLink {
+	var links []presetLink
+	for _, preset := range list {
+		links = append(links, presetLink{
+			Name:    preset.Name,
+			Query:   preset.Query,
+			Default: preset.Default,
+			Active:  preset.Query == current,
+			Href:    teamWorkInProgressQuery(preset.Query),
+		})
+	}
+
+	return links
+}
+
+// teamWorkInProgressQuery links to the page with the given filters. An empty
+// query still sets the page, so that the default preset is not applied over
+// it.
+func teamWorkInProgressQuery(query string) string {
+	if query == "" {
+		return "?page=1"
+	}
+
+	return "?" + query
+}
+
+// filterPresets saves and deletes a manager's presets. As it does not call
+// Sirius the XSRF token is checked here instead.
+func filterPresets(client FilterPresetsClient, store PresetStore) Handler {
+	return func(w http.ResponseWriter, r *http.Request) error {
+		if r.Method != http.MethodPost {
+			return StatusError(http.StatusMethodNotAllowed)
+		}
+
+		if err := r.ParseForm(); err != nil {
+			return err
+		}
+
+		if !validXSRFToken(r) {
+			return StatusError(http.StatusForbidden)
+		}
+
+		ctx := getContext(r)
+
+		myDetails, err := client.MyDetails(ctx)
+		if err != nil {
+			return err
+		}
+
+		if !myDetails.IsManager() {
+			return StatusError(http.StatusForbidden)
+		}
+
+		team, err := strconv.Atoi(r.PostForm.Get("team"))
+		if err != nil {
+			return StatusError(http.StatusBadRequest)
+		}
+
+		path := fmt.Sprintf("/teams/work-in-progress/%d", team)
+		name := strings.TrimSpace(r.PostForm.Get("name"))
+
+		switch r.PostForm.Get("action") {
+		case "save":
+			if name == "" || len(name) > maxPresetNameLength {
+				return StatusError(http.StatusBadRequest)
+			}
+
+			query, err := url.ParseQuery(r.PostForm.Get("query"))
+			if err != nil {
+				return StatusError(http.StatusBadRequest)
+			}
+
+			filters, err := newTeamWorkInProgressFilters(query)
+			if err != nil {
+				return err
+			}
+
+			preset := presets.Preset{
+				Name:    name,
+				Query:   filters.Encode(),
+				Default: r.PostForm.Get("default") == "true",
+			}
+
+			if err := store.Save(ctx.Context, myDetails.ID, preset); err != nil {
+				return err
+			}
+
+			return RedirectError(path + teamWorkInProgressQuery(preset.Query))
+
+		case "delete":
+			if err := store.Delete(ctx.Context, myDetails.ID, name); err != nil {
+				return err
+			}
+
+			return RedirectError(path + teamWorkInProgressQuery(""))
+
+		default:
+			return StatusError(http.StatusBadRequest)
+		}
+	}
+}
+
+// validXSRFToken checks that the token posted matches the one in the cookie
+// Sirius sets, as Sirius would.
+func validXSRFToken(r *http.Request) bool {
+	cookie, err := r.Cookie("XSRF-TOKEN")
+	if err != nil {
+		return false
+	}
+
+	token, err := url.QueryUnescape(cookie.Value)
+	if err != nil || token == "" {
+		return false
+	}
+
+	return subtle.ConstantTimeCompare([]byte(token), []byte(r.PostForm.Get("xsrfToken"))) == 1
+}
@@ -0,0 +1,267 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/ministryofjustice/opg-sirius-lpa-dashboard/internal/presets"
+	"github.com/ministryofjustice/opg-sirius-lpa-dashboard/internal/sirius"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockPresetStore struct {
+	list struct {
+		count      int
+		lastUserID int
+		data       []presets.Preset
+		err        error
+	}
+	save struct {
+		count      int
+		lastUserID int
+		lastPreset presets.Preset
+		err        error
+	}
+	delete struct {
+		count      int
+		lastUserID int
+		lastName   string
+		err        error
+	}
+}
+
+func (m *mockPresetStore) List(ctx context.Context, userID int) ([]presets.Preset, error) {
+	m.list.count += 1
+	m.list.lastUserID = userID
+
+	return m.list.data, m.list.err
+}
+
+func (m *mockPresetStore) Save(ctx context.Context, userID int, preset presets.Preset) error {
+	m.save.count += 1
+	m.save.lastUserID = userID
+	m.save.lastPreset = preset
+
+	return m.save.err
+}
+
+func (m *mockPresetStore) Delete(ctx context.Context, userID int, name string) error {
+	m.delete.count += 1
+	m.delete.lastUserID = userID
+	m.delete.lastName = name
+
+	return m.delete.err
+}
+
+type mockFilterPresetsClient struct {
+	myDetails struct {
+		count   int
+		lastCtx sirius.Context
+		data    sirius.MyDetails
+		err     error
+	}
+}
+
+func (m *mockFilterPresetsClient) MyDetails(ctx sirius.Context) (sirius.MyDetails, error) {
+	m.myDetails.count += 1
+	m.myDetails.lastCtx = ctx
+
+	return m.myDetails.data, m.myDetails.err
+}
+
+func newPresetRequest(form url.Values) *http.Request {
+	form.Set("xsrfToken", "abc=")
+
+	r, _ := http.NewRequest(http.MethodPost, "/filter-presets", strings.NewReader(form.Encode()))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	r.AddCookie(&http.Cookie{Name: "XSRF-TOKEN", Value: url.QueryEscape("abc=")})
+
+	return r
+}
+
+func TestPostFilterPresetsSave(t *testing.T) {
+	assert := assert.New(t)
+
+	client := &mockFilterPresetsClient{}
+	client.myDetails.data = sirius.MyDetails{ID: 14, Roles: []string{"Manager"}}
+	store := &mockPresetStore{}
+
+	w := httptest.NewRecorder()
+	r := newPresetRequest(url.Values{
+		"action":  {"save"},
+		"team":    {"66"},
+		"name":    {" Unworked HW "},
+		"query":   {"status=pending&lpa-type=hw"},
+		"default": {"true"},
+	})
+
+	err := filterPresets(client, store)(w, r)
+	assert.Equal(RedirectError("/teams/work-in-progress/66?lpa-type=hw&status=pending"), err)
+
+	assert.Equal(1, store.save.count)
+	assert.Equal(14, store.save.lastUserID)
+	assert.Equal(presets.Preset{Name: "Unworked HW", Query: "lpa-type=hw&status=pending", Default: true}, store.save.lastPreset)
+}
+
+func TestPostFilterPresetsSaveNoFilters(t *testing.T) {
+	assert := assert.New(t)
+
+	client := &mockFilterPresetsClient{}
+	client.myDetails.data = sirius.MyDetails{ID: 14, Roles: []string{"Manager"}}
+	store := &mockPresetStore{}
+
+	w := httptest.NewRecorder()
+	r := newPresetRequest(url.Values{"action": {"save"}, "team": {"66"}, "name": {"Everything"}})
+
+	err := filterPresets(client, store)(w, r)
+	assert.Equal(RedirectError("/teams/work-in-progress/66?page=1"), err)
+	assert.Equal(presets.Preset{Name: "Everything"}, store.save.lastPreset)
+}
+
+func TestPostFilterPresetsSaveInvalid(t *testing.T) {
+	testCases := map[string]struct {
+		form     url.Values
+		expected error
+	}{
+		"no name": {
+			form:     url.Values{"action": {"save"}, "team": {"66"}, "name": {"  "}},
+			expected: StatusError(http.StatusBadRequest),
+		},
+		"long name": {
+			form:     url.Values{"action": {"save"}, "team": {"66"}, "name": {strings.Repeat("a", maxPresetNameLength+1)}},
+			expected: StatusError(http.StatusBadRequest),
+		},
+		"no team": {
+			form:     url.Values{"action": {"save"}, "name": {"Mine"}},
+			expected: StatusError(http.StatusBadRequest),
+		},
+		"unknown action": {
+			form:     url.Values{"action": {"rename"}, "team": {"66"}, "name": {"Mine"}},
+			expected: StatusError(http.StatusBadRequest),
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			client := &mockFilterPresetsClient{}
+			client.myDetails.data = sirius.MyDetails{ID: 14, Roles: []string{"Manager"}}
+			store := &mockPresetStore{}
+
+			err := filterPresets(client, store)(httptest.NewRecorder(), newPresetRequest(tc.form))
+			assert.Equal(t, tc.expected, err)
+			assert.Equal(t, 0, store.save.count)
+			assert.Equal(t, 0, store.delete.count)
+		})
+	}
+}
+
+func TestPostFilterPresetsSaveBadFilter(t *testing.T) {
+	client := &mockFilterPresetsClient{}
+	client.myDetails.data = sirius.MyDetails{ID: 14, Roles: []string{"Manager"}}
+	store := &mockPresetStore{}
+
+	r := newPresetRequest(url.Values{"action": {"save"}, "team": {"66"}, "name": {"Mine"}, "query": {"status=what"}})
+
+	err := filterPresets(client, store)(httptest.NewRecorder(), r)
+	assert.IsType(t, sirius.CriteriaError{}, err)
+	assert.Equal(t, 0, store.save.count)
+}
+
+func TestPostFilterPresetsSaveError(t *testing.T) {
+	expectedError := errors.New("oops")
+
+	client := &mockFilterPresetsClient{}
+	client.myDetails.data = sirius.MyDetails{ID: 14, Roles: []string{"Manager"}}
+	store := &mockPresetStore{}
+	store.save.err = expectedError
+
+	r := newPresetRequest(url.Values{"action": {"save"}, "team": {"66"}, "name": {"Mine"}})
+
+	err := filterPresets(client, store)(httptest.NewRecorder(), r)
+	assert.Equal(t, expectedError, err)
+}
+
+func TestPostFilterPresetsDelete(t *testing.T) {
+	assert := assert.New(t)
+
+	client := &mockFilterPresetsClient{}
+	client.myDetails.data = sirius.MyDetails{ID: 14, Roles: []string{"Manager"}}
+	store := &mockPresetStore{}
+
+	w := httptest.NewRecorder()
+	r := newPresetRequest(url.Values{"action": {"delete"}, "team": {"66"}, "name": {"Mine"}})
+
+	err := filterPresets(client, store)(w, r)
+	assert.Equal(RedirectError("/teams/work-in-progress/66?page=1"), err)
+
+	assert.Equal(1, store.delete.count)
+	assert.Equal(14, store.delete.lastUserID)
+	assert.Equal("Mine", store.delete.lastName)
+}
+
+func TestPostFilterPresetsForbidden(t *testing.T) {
+	assert := assert.New(t)
+
+	client := &mockFilterPresetsClient{}
+	client.myDetails.data = sirius.MyDetails{ID: 14, Roles: []string{"Case Manager"}}
+	store := &mockPresetStore{}
+
+	r := newPresetRequest(url.Values{"action": {"delete"}, "team": {"66"}, "name": {"Mine"}})
+
+	err := filterPresets(client, store)(httptest.NewRecorder(), r)
+	assert.Equal(StatusError(http.StatusForbidden), err)
+	assert.Equal(0, store.delete.count)
+}
+
+func TestPostFilterPresetsBadXSRFToken(t *testing.T) {
+	assert := assert.New(t)
+
+	client := &mockFilterPresetsClient{}
+	store := &mockPresetStore{}
+
+	r := newPresetRequest(url.Values{"action": {"delete"}, "team": {"66"}, "name": {"Mine"}})
+	r.Header.Del("Cookie")
+	r.AddCookie(&http.Cookie{Name: "XSRF-TOKEN", Value: "other"})
+
+	err := filterPresets(client, store)(httptest.NewRecorder(), r)
+	assert.Equal(StatusError(http.StatusForbidden), err)
+
+	assert.Equal(0, client.myDetails.count)
+	assert.Equal(0, store.delete.count)
+}
+
+func TestPostFilterPresetsMyDetailsError(t *testing.T) {
+	expectedError := errors.New("oops")
+
+	client := &mockFilterPresetsClient{}
+	client.myDetails.err = expectedError
+
+	r := newPresetRequest(url.Values{"action": {"delete"}, "team": {"66"}, "name": {"Mine"}})
+
+	err := filterPresets(client, &mockPresetStore{})(httptest.NewRecorder(), r)
+	assert.Equal(t, expectedError, err)
+}
+
+func TestBadMethodFilterPresets(t *testing.T) {
+	r, _ := http.NewRequest(http.MethodGet, "/filter-presets", nil)
+
+	err := filterPresets(nil, nil)(httptest.NewRecorder(), r)
+	assert.Equal(t, StatusError(http.StatusMethodNotAllowed), err)
+}
+
+func TestNewPresetLinks(t *testing.T) {
+	links := newPresetLinks([]presets.Preset{
+		{Name: "Mine", Query: "allocation=1", Default: true},
+		{Name: "Everything"},
+	}, "allocation=1")
+
+	assert.Equal(t, []presetLink{
+		{Name: "Mine", Query: "allocation=1", Default: true, Active: true, Href: "?allocation=1"},
+		{Name: "Everything", Href: "?page=1"},
+	}, links)
+}
@@ -0,0 +1,110 @@
+package server
+
+import (
+	"encoding/json"
+	"html/template"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// jsonVersion must be increased whenever a field is renamed or removed.
+const jsonVersion = 1
+
+type jsonDocument struct {
+	Version int         `json:"version"`
+	View    string      `json:"view"`
+	Data    interface{} `json:"data"`
+}
+
+type jsonResponseWriter struct {
+	http.ResponseWriter
+	wroteHeader bool
+}
+
+func (w *jsonResponseWriter) WriteHeader(code int) {
+	if !w.wroteHeader {
+		w.wroteHeader = true
+		if w.Header().Get("Content-Type") == "" {
+			w.Header().Set("Content-Type", "application/json")
+		}
+	}
+
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *jsonResponseWriter) Write(b []byte) (int, error) {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+
+	return w.ResponseWriter.Write(b)
+}
+
+func wantsJSON(w io.Writer) bool {
+	_, ok := w.(*jsonResponseWriter)
+	return ok
+}
+
+func negotiate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Vary", "Accept")
+
+		if acceptsJSON(r.Header.Get("Accept")) {
+			w = &jsonResponseWriter{ResponseWriter: w}
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// When both are given the same weight the one listed first wins.
+func acceptsJSON(accept string) bool {
+	best, bestQ := "", 0.0
+
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil || (mediaType != "application/json" && mediaType != "text/html") {
+			continue
+		}
+
+		q := 1.0
+		if v, ok := params["q"]; ok {
+			q, _ = strconv.ParseFloat(v, 64)
+		}
+
+		if q > bestQ {
+			best, bestQ = mediaType, q
+		}
+	}
+
+	return best == "application/json"
+}
+
+type viewTemplate struct {
+	Template
+	view string
+}
+
+func (t viewTemplate) ExecuteTemplate(w io.Writer, name string, vars interface{}) error {
+	if wantsJSON(w) {
+		return json.NewEncoder(w).Encode(jsonDocument{
+			Version: jsonVersion,
+			View:    t.view,
+			Data:    vars,
+		})
+	}
+
+	return t.Template.ExecuteTemplate(w, name, vars)
+}
+
+func viewTemplates(templates map[string]*template.Template) map[string]Template {
+	views := make(map[string]Template, len(templates))
+	for name, tmpl := range templates {
+		views[name] = viewTemplate{Template: tmpl, view: strings.TrimSuffix(name, ".gotmpl")}
+	}
+
+	return views
+}
@@ -0,0 +1,101 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ministryofjustice/opg-sirius-lpa-dashboard/internal/sirius"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAcceptsJSON(t *testing.T) {
+	testCases := map[string]bool{
+		"":                                 false,
+		"*/*":                              false,
+		"application/json":                 true,
+		"application/json; charset=utf-8":  true,
+		"text/html,application/json":       false,
+		"application/json,text/html":       true,
+		"text/html;q=0.5,application/json": true,
+		"application/json;q=0,text/html":   false,
+		"text/html,application/xhtml+xml,application/xml;q=0.9,*/*;q=0.8": false,
+	}
+
+	for accept, expected := range testCases {
+		t.Run(accept, func(t *testing.T) {
+			assert.Equal(t, expected, acceptsJSON(accept))
+		})
+	}
+}
+
+func TestNegotiateJSON(t *testing.T) {
+	assert := assert.New(t)
+
+	tmpl := viewTemplate{Template: &mockTemplate{}, view: "pending-cases"}
+	handler := negotiate(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = tmpl.ExecuteTemplate(w, "page", pendingCasesVars{
+			Cases:      []sirius.Case{{ID: 58, Uid: "7000-2830-9492"}},
+			Pagination: &Pagination{Query: "?", TotalItems: 1, CurrentPage: 1, TotalPages: 1, PageSize: 25},
+			IsManager:  true,
+			XSRFToken:  "abc",
+			Sort:       Sort{Field: "receiptDate", Order: "asc", Chosen: "receiptDate:asc"},
+		})
+	}))
+
+	w := httptest.NewRecorder()
+	r, _ := http.NewRequest("GET", "/pending-cases", nil)
+	r.Header.Set("Accept", "application/json")
+
+	handler.ServeHTTP(w, r)
+
+	resp := w.Result()
+	assert.Equal(http.StatusOK, resp.StatusCode)
+	assert.Equal("application/json", resp.Header.Get("Content-Type"))
+	assert.Equal("Accept", resp.Header.Get("Vary"))
+	assert.JSONEq(`{
+		"version": 1,
+		"view": "pending-cases",
+		"data": {
+			"cases": [{
+				"id": 58,
+				"uId": "7000-2830-9492",
+				"donor": {"id": 0, "uId": "", "firstname": "", "surname": ""},
+				"caseSubtype": "",
+				"receiptDate": null,
+				"status": "",
+				"taskCount": 0,
+				"worked": false,
+				"assignee": {"id": 0, "displayName": "", "teams": null}
+			}],
+			"pagination": {"totalItems": 1, "currentPage": 1, "totalPages": 1, "pageSize": 25},
+			"hasWorkableCase": false,
+			"canRequestCase": false,
+			"isManager": true,
+			"undoCases": null,
+			"workedAt": "",
+			"undoSignature": "",
+			"sort": {"field": "receiptDate", "order": "asc"}
+		}
+	}`, w.Body.String())
+}
+
+func TestNegotiateHTML(t *testing.T) {
+	assert := assert.New(t)
+
+	template := &mockTemplate{}
+	tmpl := viewTemplate{Template: template, view: "pending-cases"}
+	handler := negotiate(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = tmpl.ExecuteTemplate(w, "page", pendingCasesVars{})
+	}))
+
+	w := httptest.NewRecorder()
+	r, _ := http.NewRequest("GET", "/pending-cases", nil)
+	r.Header.Set("Accept", "text/html")
+
+	handler.ServeHTTP(w, r)
+
+	assert.Equal(1, template.count)
+	assert.Equal("page", template.lastName)
+	assert.Equal("Accept", w.Result().Header.Get("Vary"))
+}
@@ -1,17 +1,44 @@
 package server
 
 import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
 	"net/http"
+	"net/url"
 	"strconv"
+	"sync"
+	"time"
 
 	"github.com/ministryofjustice/opg-sirius-lpa-dashboard/internal/sirius"
 )
 
+// markWorkedConcurrency limits how many cases are marked as worked at once, so
+// that a large selection does not flood Sirius with requests.
+const markWorkedConcurrency = 4
+
+// undoWindow is how long after marking cases as worked the caseworker is
+// offered the chance to undo it.
+const undoWindow = 5 * time.Minute
+
 type MarkWorkedClient interface {
 	MarkWorked(sirius.Context, int) error
 }
 
-func markWorked(client MarkWorkedClient) Handler {
+type MarkUnworkedClient interface {
+	MarkUnworked(sirius.Context, int) error
+}
+
+type markWorkedVars struct {
+	XSRFToken     string        `json:"-"`
+	Undo          bool          `json:"undo"`
+	Worked        []int         `json:"worked"`
+	WorkedAt      int64         `json:"workedAt"`
+	UndoSignature string        `json:"undoSignature"`
+	NotWorked     []caseFailure `json:"notWorked"`
+}
+
+func markWorked(client MarkWorkedClient, tmpl Template, undo undoSigner) Handler {
 	return func(w http.ResponseWriter, r *http.Request) error {
 		if r.Method != http.MethodPost {
 			return StatusError(http.StatusMethodNotAllowed)
@@ -23,17 +50,171 @@ func markWorked(client MarkWorkedClient) Handler {
 
 		ctx := getContext(r)
 
-		for _, workedID := range r.PostForm["worked"] {
-			id, err := strconv.Atoi(workedID)
-			if err != nil {
-				return err
+		ids, err := formIDs(r.PostForm["worked"])
+		if err != nil {
+			return err
+		}
+
+		errs := markAll(ids, func(id int) error {
+			return client.MarkWorked(ctx, id)
+		})
+
+		vars := markWorkedVars{
+			XSRFToken: ctx.XSRFToken,
+			WorkedAt:  time.Now().Unix(),
+		}
+
+		for i, id := range ids {
+			switch errs[i] {
+			case nil:
+				vars.Worked = append(vars.Worked, id)
+			case sirius.ErrUnauthorized:
+				return errs[i]
+			default:
+				vars.NotWorked = append(vars.NotWorked, newCaseFailure(id, errs[i], "Sirius could not mark this case as worked"))
 			}
+		}
 
-			if err := client.MarkWorked(ctx, id); err != nil {
-				return err
+		if len(vars.NotWorked) == 0 {
+			if len(vars.Worked) == 0 {
+				return RedirectError("/pending-cases")
 			}
+
+			return RedirectError("/pending-cases?" + undo.query(vars.Worked, vars.WorkedAt).Encode())
 		}
 
-		return RedirectError("/pending-cases")
+		if len(vars.Worked) > 0 {
+			vars.UndoSignature = undo.sign(vars.Worked, vars.WorkedAt)
+		}
+
+		return tmpl.ExecuteTemplate(w, "page", vars)
 	}
 }
+
+func markUnworked(client MarkUnworkedClient, tmpl Template, undo undoSigner) Handler {
+	return func(w http.ResponseWriter, r *http.Request) error {
+		if r.Method != http.MethodPost {
+			return StatusError(http.StatusMethodNotAllowed)
+		}
+
+		if err := r.ParseForm(); err != nil {
+			return err
+		}
+
+		ctx := getContext(r)
+
+		ids, ok := undo.cases(r.PostForm, time.Now())
+		if !ok {
+			return StatusError(http.StatusBadRequest)
+		}
+
+		errs := markAll(ids, func(id int) error {
+			return client.MarkUnworked(ctx, id)
+		})
+
+		vars := markWorkedVars{
+			XSRFToken: ctx.XSRFToken,
+			Undo:      true,
+		}
+
+		for i, id := range ids {
+			switch errs[i] {
+			case nil:
+				vars.Worked = append(vars.Worked, id)
+			case sirius.ErrUnauthorized:
+				return errs[i]
+			default:
+				vars.NotWorked = append(vars.NotWorked, newCaseFailure(id, errs[i], "Sirius could not mark this case as pending"))
+			}
+		}
+
+		if len(vars.NotWorked) == 0 {
+			return RedirectError("/pending-cases")
+		}
+
+		return tmpl.ExecuteTemplate(w, "page", vars)
+	}
+}
+
+// markAll calls fn for each case, returning the error for each case in the
+// same order as ids.
+func markAll(ids []int, fn func(int) error) []error {
+	errs := make([]error, len(ids))
+	sem := make(chan struct{}, markWorkedConcurrency)
+
+	var wg sync.WaitGroup
+	for i, id := range ids {
+		sem <- struct{}{}
+		wg.Go(func() {
+			defer func() { <-sem }()
+			errs[i] = fn(id)
+		})
+	}
+
+	wg.Wait()
+	return errs
+}
+
+func formIDs(values []string) ([]int, error) {
+	var ids []int
+	for _, v := range values {
+		id, err := strconv.Atoi(v)
+		if err != nil {
+			return nil, err
+		}
+
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
+
+// undoSigner signs the cases marked as worked, and when, so that only undo
+// requests made by the dashboard within the undo window are accepted.
+type undoSigner []byte
+
+func (k undoSigner) sign(ids []int, workedAt int64) string {
+	mac := hmac.New(sha256.New, k)
+	mac.Write([]byte(strconv.FormatInt(workedAt, 10)))
+	for _, id := range ids {
+		mac.Write([]byte("," + strconv.Itoa(id)))
+	}
+
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func (k undoSigner) query(ids []int, workedAt int64) url.Values {
+	query := url.Values{
+		"workedAt": {strconv.FormatInt(workedAt, 10)},
+		"undo":     {k.sign(ids, workedAt)},
+	}
+	for _, id := range ids {
+		query.Add("worked", strconv.Itoa(id))
+	}
+
+	return query
+}
+
+// cases returns the cases recorded by query, provided the signature matches
+// and they were marked as worked within the undo window.
+func (k undoSigner) cases(form url.Values, now time.Time) ([]int, bool) {
+	workedAt, err := strconv.ParseInt(form.Get("workedAt"), 10, 64)
+	if err != nil {
+		return nil, false
+	}
+
+	if age := now.Sub(time.Unix(workedAt, 0)); age < 0 || age > undoWindow {
+		return nil, false
+	}
+
+	ids, err := formIDs(form["worked"])
+	if err != nil || len(ids) == 0 {
+		return nil, false
+	}
+
+	if !hmac.Equal([]byte(form.Get("undo")), []byte(k.sign(ids, workedAt))) {
+		return nil, false
+	}
+
+	return ids, true
+}
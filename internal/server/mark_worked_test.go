@@ -4,30 +4,66 @@ import (
 	"errors"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
+	"strconv"
 	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/ministryofjustice/opg-sirius-lpa-dashboard/internal/sirius"
 	"github.com/stretchr/testify/assert"
 )
 
 type mockMarkWorkedClient struct {
+	mu         sync.Mutex
 	markWorked struct {
 		count   int
 		lastCtx sirius.Context
 		err     error
+		errs    map[int]error
 		ids     []int
 	}
 }
 
+type mockMarkUnworkedClient struct {
+	mu           sync.Mutex
+	markUnworked struct {
+		count   int
+		lastCtx sirius.Context
+		errs    map[int]error
+		ids     []int
+	}
+}
+
+func (m *mockMarkUnworkedClient) MarkUnworked(ctx sirius.Context, id int) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.markUnworked.count += 1
+	m.markUnworked.lastCtx = ctx
+	m.markUnworked.ids = append(m.markUnworked.ids, id)
+
+	return m.markUnworked.errs[id]
+}
+
 func (m *mockMarkWorkedClient) MarkWorked(ctx sirius.Context, id int) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
 	m.markWorked.count += 1
 	m.markWorked.lastCtx = ctx
 	m.markWorked.ids = append(m.markWorked.ids, id)
 
+	if err, ok := m.markWorked.errs[id]; ok {
+		return err
+	}
+
 	return m.markWorked.err
 }
 
+var testUndo = undoSigner("test-key")
+
 func TestPostMarkWorked(t *testing.T) {
 	assert := assert.New(t)
 
@@ -37,12 +73,21 @@ func TestPostMarkWorked(t *testing.T) {
 	r, _ := http.NewRequest("POST", "/path", strings.NewReader("worked=12&worked=34"))
 	r.Header.Add("Content-Type", "application/x-www-form-urlencoded")
 
-	err := markWorked(client)(w, r)
-	assert.Equal(RedirectError("/pending-cases"), err)
+	before := time.Now().Unix()
+	err := markWorked(client, nil, testUndo)(w, r)
+	assert.IsType(RedirectError(""), err)
+
+	redirect, _ := url.Parse(err.(RedirectError).To())
+	assert.Equal("/pending-cases", redirect.Path)
+	assert.Equal([]string{"12", "34"}, redirect.Query()["worked"])
+
+	workedAt, _ := strconv.ParseInt(redirect.Query().Get("workedAt"), 10, 64)
+	assert.GreaterOrEqual(workedAt, before)
+	assert.Equal(testUndo.sign([]int{12, 34}, workedAt), redirect.Query().Get("undo"))
 
 	assert.Equal(2, client.markWorked.count)
 	assert.Equal(getContext(r), client.markWorked.lastCtx)
-	assert.Equal([]int{12, 34}, client.markWorked.ids)
+	assert.ElementsMatch([]int{12, 34}, client.markWorked.ids)
 }
 
 func TestPostMarkWorkedNoForm(t *testing.T) {
@@ -53,7 +98,7 @@ func TestPostMarkWorkedNoForm(t *testing.T) {
 	w := httptest.NewRecorder()
 	r, _ := http.NewRequest("POST", "/path", nil)
 
-	err := markWorked(client)(w, r)
+	err := markWorked(client, nil, testUndo)(w, r)
 	assert.NotNil(err)
 
 	assert.Equal(0, client.markWorked.count)
@@ -68,7 +113,7 @@ func TestPostMarkWorkedBadId(t *testing.T) {
 	r, _ := http.NewRequest("POST", "/path", strings.NewReader("worked=what"))
 	r.Header.Add("Content-Type", "application/x-www-form-urlencoded")
 
-	err := markWorked(client)(w, r)
+	err := markWorked(client, nil, testUndo)(w, r)
 	assert.NotNil(err)
 
 	assert.Equal(0, client.markWorked.count)
@@ -78,14 +123,53 @@ func TestPostMarkWorkedErrors(t *testing.T) {
 	assert := assert.New(t)
 
 	client := &mockMarkWorkedClient{}
-	client.markWorked.err = errors.New("err")
+	client.markWorked.errs = map[int]error{
+		2: errors.New("err"),
+		4: &sirius.StatusError{
+			Code:    http.StatusBadRequest,
+			Problem: &sirius.Problem{Title: "Bad request", Detail: "Case is not pending"},
+		},
+	}
+	template := &mockTemplate{}
 
 	w := httptest.NewRecorder()
-	r, _ := http.NewRequest("POST", "/path", strings.NewReader("worked=1"))
+	r, _ := http.NewRequest("POST", "/path", strings.NewReader("worked=1&worked=2&worked=3&worked=4&worked=5"))
 	r.Header.Add("Content-Type", "application/x-www-form-urlencoded")
 
-	err := markWorked(client)(w, r)
-	assert.Equal(client.markWorked.err, err)
+	err := markWorked(client, template, testUndo)(w, r)
+	assert.Nil(err)
+
+	assert.Equal(5, client.markWorked.count)
+	assert.Equal(1, template.count)
+	assert.Equal("page", template.lastName)
+
+	vars := template.lastVars.(markWorkedVars)
+	assert.NotZero(vars.WorkedAt)
+	assert.Equal(testUndo.sign([]int{1, 3, 5}, vars.WorkedAt), vars.UndoSignature)
+	vars.WorkedAt = 0
+	vars.UndoSignature = ""
+
+	assert.Equal(markWorkedVars{
+		Worked: []int{1, 3, 5},
+		NotWorked: []caseFailure{
+			{ID: 2, Reason: "Sirius could not mark this case as worked"},
+			{ID: 4, Reason: "Bad request"},
+		},
+	}, vars)
+}
+
+func TestPostMarkWorkedUnauthorized(t *testing.T) {
+	assert := assert.New(t)
+
+	client := &mockMarkWorkedClient{}
+	client.markWorked.errs = map[int]error{2: sirius.ErrUnauthorized}
+
+	w := httptest.NewRecorder()
+	r, _ := http.NewRequest("POST", "/path", strings.NewReader("worked=1&worked=2"))
+	r.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+
+	err := markWorked(client, nil, testUndo)(w, r)
+	assert.Equal(sirius.ErrUnauthorized, err)
 }
 
 func TestBadMethodMarkWorked(t *testing.T) {
@@ -96,9 +180,135 @@ func TestBadMethodMarkWorked(t *testing.T) {
 	w := httptest.NewRecorder()
 	r, _ := http.NewRequest("DELETE", "/path", nil)
 
-	err := markWorked(client)(w, r)
+	err := markWorked(client, nil, testUndo)(w, r)
 
 	assert.Equal(StatusError(http.StatusMethodNotAllowed), err)
 
 	assert.Equal(0, client.markWorked.count)
 }
+
+func TestPostMarkUnworked(t *testing.T) {
+	assert := assert.New(t)
+
+	client := &mockMarkUnworkedClient{}
+
+	form := testUndo.query([]int{12, 34}, time.Now().Unix())
+
+	w := httptest.NewRecorder()
+	r, _ := http.NewRequest("POST", "/path", strings.NewReader(form.Encode()))
+	r.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+
+	err := markUnworked(client, nil, testUndo)(w, r)
+	assert.Equal(RedirectError("/pending-cases"), err)
+
+	assert.Equal(2, client.markUnworked.count)
+	assert.Equal(getContext(r), client.markUnworked.lastCtx)
+	assert.ElementsMatch([]int{12, 34}, client.markUnworked.ids)
+}
+
+func TestPostMarkUnworkedErrors(t *testing.T) {
+	assert := assert.New(t)
+
+	client := &mockMarkUnworkedClient{}
+	client.markUnworked.errs = map[int]error{34: errors.New("err")}
+	template := &mockTemplate{}
+
+	form := testUndo.query([]int{12, 34}, time.Now().Unix())
+
+	w := httptest.NewRecorder()
+	r, _ := http.NewRequest("POST", "/path", strings.NewReader(form.Encode()))
+	r.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+
+	err := markUnworked(client, template, testUndo)(w, r)
+	assert.Nil(err)
+
+	assert.Equal(markWorkedVars{
+		Undo:   true,
+		Worked: []int{12},
+		NotWorked: []caseFailure{
+			{ID: 34, Reason: "Sirius could not mark this case as pending"},
+		},
+	}, template.lastVars)
+}
+
+func TestPostMarkUnworkedExpired(t *testing.T) {
+	assert := assert.New(t)
+
+	client := &mockMarkUnworkedClient{}
+
+	form := testUndo.query([]int{12}, time.Now().Add(-undoWindow-time.Minute).Unix())
+
+	w := httptest.NewRecorder()
+	r, _ := http.NewRequest("POST", "/path", strings.NewReader(form.Encode()))
+	r.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+
+	err := markUnworked(client, nil, testUndo)(w, r)
+	assert.Equal(StatusError(http.StatusBadRequest), err)
+	assert.Equal(0, client.markUnworked.count)
+}
+
+func TestPostMarkUnworkedTampered(t *testing.T) {
+	assert := assert.New(t)
+
+	client := &mockMarkUnworkedClient{}
+
+	form := testUndo.query([]int{12}, time.Now().Add(-time.Hour).Unix())
+	form.Set("workedAt", strconv.FormatInt(time.Now().Unix(), 10))
+
+	w := httptest.NewRecorder()
+	r, _ := http.NewRequest("POST", "/path", strings.NewReader(form.Encode()))
+	r.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+
+	err := markUnworked(client, nil, testUndo)(w, r)
+	assert.Equal(StatusError(http.StatusBadRequest), err)
+	assert.Equal(0, client.markUnworked.count)
+}
+
+func TestBadMethodMarkUnworked(t *testing.T) {
+	assert := assert.New(t)
+
+	client := &mockMarkUnworkedClient{}
+
+	w := httptest.NewRecorder()
+	r, _ := http.NewRequest("GET", "/path", nil)
+
+	err := markUnworked(client, nil, testUndo)(w, r)
+	assert.Equal(StatusError(http.StatusMethodNotAllowed), err)
+	assert.Equal(0, client.markUnworked.count)
+}
+
+func TestUndoSignerCases(t *testing.T) {
+	now := time.Date(2021, 5, 12, 9, 0, 0, 0, time.UTC)
+	recent := now.Add(-time.Minute).Unix()
+
+	tampered := func(ids []int, workedAt int64, key, value string) url.Values {
+		form := testUndo.query(ids, workedAt)
+		form.Set(key, value)
+		return form
+	}
+
+	testCases := map[string]struct {
+		Form url.Values
+		IDs  []int
+		OK   bool
+	}{
+		"recent":         {Form: testUndo.query([]int{1, 2}, recent), IDs: []int{1, 2}, OK: true},
+		"expired":        {Form: testUndo.query([]int{1}, now.Add(-undoWindow-time.Second).Unix())},
+		"future":         {Form: testUndo.query([]int{1}, now.Add(time.Minute).Unix())},
+		"tampered time":  {Form: tampered([]int{1}, now.Add(-time.Hour).Unix(), "workedAt", strconv.FormatInt(recent, 10))},
+		"tampered cases": {Form: tampered([]int{1}, recent, "worked", "2")},
+		"other key":      {Form: undoSigner("other-key").query([]int{1}, recent)},
+		"no signature":   {Form: tampered([]int{1}, recent, "undo", "")},
+		"no time":        {Form: url.Values{"worked": {"1"}}},
+		"no cases":       {Form: tampered(nil, recent, "worked", "")},
+		"bad case id":    {Form: tampered([]int{1}, recent, "worked", "x")},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			ids, ok := testUndo.cases(tc.Form, now)
+			assert.Equal(t, tc.OK, ok)
+			assert.Equal(t, tc.IDs, ids)
+		})
+	}
+}
@@ -0,0 +1,153 @@
+package server
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ministryofjustice/opg-sirius-lpa-dashboard/internal/sirius"
+)
+
+type myDetailsContextKey struct{}
+
+type requestMyDetails struct {
+	once    sync.Once
+	details sirius.MyDetails
+	err     error
+}
+
+type myDetailsEntry struct {
+	details sirius.MyDetails
+	expires time.Time
+}
+
+type myDetailsCache struct {
+	Client
+	ttl time.Duration
+	now func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]myDetailsEntry
+}
+
+func newMyDetailsCache(client Client, ttl time.Duration) *myDetailsCache {
+	return &myDetailsCache{
+		Client:  client,
+		ttl:     ttl,
+		now:     time.Now,
+		entries: map[string]myDetailsEntry{},
+	}
+}
+
+func (c *myDetailsCache) MyDetails(ctx sirius.Context) (sirius.MyDetails, error) {
+	if memo, ok := ctx.Context.Value(myDetailsContextKey{}).(*requestMyDetails); ok {
+		memo.once.Do(func() {
+			memo.details, memo.err = c.lookup(ctx)
+		})
+
+		return memo.details, memo.err
+	}
+
+	return c.lookup(ctx)
+}
+
+func (c *myDetailsCache) lookup(ctx sirius.Context) (sirius.MyDetails, error) {
+	key := sessionKey(ctx.Cookies)
+
+	if key != "" && c.ttl > 0 {
+		c.mu.Lock()
+		entry, ok := c.entries[key]
+		c.mu.Unlock()
+
+		if ok && c.now().Before(entry.expires) {
+			return entry.details, nil
+		}
+	}
+
+	details, err := c.Client.MyDetails(ctx)
+	if err != nil {
+		if err == sirius.ErrUnauthorized {
+			c.invalidate(ctx.Cookies)
+		}
+
+		return details, err
+	}
+
+	if key != "" && c.ttl > 0 {
+		c.mu.Lock()
+		c.removeExpired()
+		c.entries[key] = myDetailsEntry{details: details, expires: c.now().Add(c.ttl)}
+		c.mu.Unlock()
+	}
+
+	return details, nil
+}
+
+func (c *myDetailsCache) removeExpired() {
+	now := c.now()
+	for key, entry := range c.entries {
+		if !now.Before(entry.expires) {
+			delete(c.entries, key)
+		}
+	}
+}
+
+func (c *myDetailsCache) invalidate(cookies []*http.Cookie) {
+	key := sessionKey(cookies)
+	if key == "" {
+		return
+	}
+
+	c.mu.Lock()
+	delete(c.entries, key)
+	c.mu.Unlock()
+}
+
+func (c *myDetailsCache) handle(next Handler) Handler {
+	return func(w http.ResponseWriter, r *http.Request) error {
+		r = r.WithContext(context.WithValue(r.Context(), myDetailsContextKey{}, &requestMyDetails{}))
+
+		err := next(w, r)
+		if err == sirius.ErrUnauthorized {
+			c.invalidate(r.Cookies())
+		}
+
+		return err
+	}
+}
+
+// The XSRF token and page size cookies are not part of the Sirius session.
+func sessionKey(cookies []*http.Cookie) string {
+	var values []string
+	for _, cookie := range cookies {
+		if cookie.Name != "XSRF-TOKEN" && cookie.Name != pageSizeCookie {
+			values = append(values, cookie.Name+"="+cookie.Value)
+		}
+	}
+
+	if len(values) == 0 {
+		return ""
+	}
+
+	sort.Strings(values)
+
+	hash := sha256.New()
+	for _, v := range values {
+		hash.Write([]byte(v))
+		hash.Write([]byte{0})
+	}
+
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+func logout(cache *myDetailsCache, siriusPublicURL string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		cache.invalidate(r.Cookies())
+
+		http.Redirect(w, r, siriusPublicURL+"/auth/logout", http.StatusFound)
+	}
+}
@@ -0,0 +1,184 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ministryofjustice/opg-sirius-lpa-dashboard/internal/sirius"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockMyDetailsClient struct {
+	Client
+	myDetails struct {
+		count int
+		data  sirius.MyDetails
+		err   error
+	}
+}
+
+func (m *mockMyDetailsClient) MyDetails(ctx sirius.Context) (sirius.MyDetails, error) {
+	m.myDetails.count += 1
+
+	return m.myDetails.data, m.myDetails.err
+}
+
+func sessionContext(r *http.Request) sirius.Context {
+	r.AddCookie(&http.Cookie{Name: "sirius", Value: "session-1"})
+	r.AddCookie(&http.Cookie{Name: "XSRF-TOKEN", Value: "abc"})
+
+	return getContext(r)
+}
+
+func TestMyDetailsCache(t *testing.T) {
+	assert := assert.New(t)
+
+	now := time.Date(2021, 5, 12, 9, 0, 0, 0, time.UTC)
+
+	client := &mockMyDetailsClient{}
+	client.myDetails.data = sirius.MyDetails{ID: 14}
+
+	cache := newMyDetailsCache(client, time.Minute)
+	cache.now = func() time.Time { return now }
+
+	r, _ := http.NewRequest("GET", "/path", nil)
+	ctx := sessionContext(r)
+
+	details, err := cache.MyDetails(ctx)
+	assert.Nil(err)
+	assert.Equal(client.myDetails.data, details)
+
+	details, err = cache.MyDetails(ctx)
+	assert.Nil(err)
+	assert.Equal(client.myDetails.data, details)
+	assert.Equal(1, client.myDetails.count)
+
+	now = now.Add(time.Minute)
+	_, _ = cache.MyDetails(ctx)
+	assert.Equal(2, client.myDetails.count)
+
+	other, _ := http.NewRequest("GET", "/path", nil)
+	other.AddCookie(&http.Cookie{Name: "sirius", Value: "session-2"})
+	_, _ = cache.MyDetails(getContext(other))
+	assert.Equal(3, client.myDetails.count)
+}
+
+func TestMyDetailsCacheWithoutSession(t *testing.T) {
+	assert := assert.New(t)
+
+	client := &mockMyDetailsClient{}
+	cache := newMyDetailsCache(client, time.Minute)
+
+	r, _ := http.NewRequest("GET", "/path", nil)
+	r.AddCookie(&http.Cookie{Name: "XSRF-TOKEN", Value: "abc"})
+
+	_, _ = cache.MyDetails(getContext(r))
+	_, _ = cache.MyDetails(getContext(r))
+	assert.Equal(2, client.myDetails.count)
+}
+
+func TestMyDetailsCacheDisabled(t *testing.T) {
+	assert := assert.New(t)
+
+	client := &mockMyDetailsClient{}
+	cache := newMyDetailsCache(client, 0)
+
+	r, _ := http.NewRequest("GET", "/path", nil)
+	ctx := sessionContext(r)
+
+	_, _ = cache.MyDetails(ctx)
+	_, _ = cache.MyDetails(ctx)
+	assert.Equal(2, client.myDetails.count)
+}
+
+func TestMyDetailsCacheError(t *testing.T) {
+	assert := assert.New(t)
+
+	client := &mockMyDetailsClient{}
+	client.myDetails.err = errors.New("oops")
+	cache := newMyDetailsCache(client, time.Minute)
+
+	r, _ := http.NewRequest("GET", "/path", nil)
+	ctx := sessionContext(r)
+
+	_, err := cache.MyDetails(ctx)
+	assert.Equal(client.myDetails.err, err)
+
+	client.myDetails.err = nil
+	_, err = cache.MyDetails(ctx)
+	assert.Nil(err)
+	assert.Equal(2, client.myDetails.count)
+}
+
+func TestMyDetailsCacheHandleIsRequestScoped(t *testing.T) {
+	assert := assert.New(t)
+
+	client := &mockMyDetailsClient{}
+	cache := newMyDetailsCache(client, 0)
+
+	handler := cache.handle(func(w http.ResponseWriter, r *http.Request) error {
+		_, _ = cache.MyDetails(getContext(r))
+		_, _ = cache.MyDetails(getContext(r))
+		return nil
+	})
+
+	w := httptest.NewRecorder()
+	r, _ := http.NewRequest("GET", "/path", nil)
+
+	assert.Nil(handler(w, r))
+	assert.Nil(handler(w, r))
+	assert.Equal(2, client.myDetails.count)
+}
+
+func TestMyDetailsCacheHandleUnauthorized(t *testing.T) {
+	assert := assert.New(t)
+
+	client := &mockMyDetailsClient{}
+	cache := newMyDetailsCache(client, time.Minute)
+
+	r, _ := http.NewRequest("GET", "/path", nil)
+	_, _ = cache.MyDetails(sessionContext(r))
+	assert.Len(cache.entries, 1)
+
+	handler := cache.handle(func(w http.ResponseWriter, r *http.Request) error {
+		return sirius.ErrUnauthorized
+	})
+
+	err := handler(httptest.NewRecorder(), r)
+	assert.Equal(sirius.ErrUnauthorized, err)
+	assert.Len(cache.entries, 0)
+}
+
+func TestLogout(t *testing.T) {
+	assert := assert.New(t)
+
+	client := &mockMyDetailsClient{}
+	cache := newMyDetailsCache(client, time.Minute)
+
+	r, _ := http.NewRequest("GET", "/logout", nil)
+	_, _ = cache.MyDetails(sessionContext(r))
+	assert.Len(cache.entries, 1)
+
+	w := httptest.NewRecorder()
+	logout(cache, "http://sirius")(w, r)
+
+	resp := w.Result()
+	assert.Equal(http.StatusFound, resp.StatusCode)
+	assert.Equal("http://sirius/auth/logout", resp.Header.Get("Location"))
+	assert.Len(cache.entries, 0)
+}
+
+func TestSessionKey(t *testing.T) {
+	assert := assert.New(t)
+
+	a := sessionKey([]*http.Cookie{{Name: "sirius", Value: "1"}, {Name: "other", Value: "2"}})
+	b := sessionKey([]*http.Cookie{{Name: "other", Value: "2"}, {Name: "sirius", Value: "1"}, {Name: "XSRF-TOKEN", Value: "x"}, {Name: pageSizeCookie, Value: "50"}})
+	c := sessionKey([]*http.Cookie{{Name: "sirius", Value: "3"}})
+
+	assert.Equal(a, b)
+	assert.NotEqual(a, c)
+	assert.Equal("", sessionKey([]*http.Cookie{{Name: "XSRF-TOKEN", Value: "x"}}))
+}
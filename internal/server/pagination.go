@@ -1,13 +1,23 @@
 package server
 
-import "github.com/ministryofjustice/opg-sirius-lpa-dashboard/internal/sirius"
+import (
+	"net/http"
+	"slices"
+	"strconv"
+
+	"github.com/ministryofjustice/opg-sirius-lpa-dashboard/internal/sirius"
+)
+
+const pageSizeCookie = "lpa-dashboard-page-size"
+
+var pageSizes = []int{25, 50, 100}
 
 type Pagination struct {
-	Query       string
-	TotalItems  int
-	CurrentPage int
-	TotalPages  int
-	PageSize    int
+	Query       string `json:"-"`
+	TotalItems  int    `json:"totalItems"`
+	CurrentPage int    `json:"currentPage"`
+	TotalPages  int    `json:"totalPages"`
+	PageSize    int    `json:"pageSize"`
 }
 
 func newPagination(p *sirius.Pagination) *Pagination {
@@ -19,14 +29,8 @@ func newPaginationWithQuery(p *sirius.Pagination, q string) *Pagination {
 		return nil
 	}
 
-	if q == "" {
-		q = "?"
-	} else {
-		q = "?" + q + "&"
-	}
-
 	return &Pagination{
-		Query:       q,
+		Query:       queryPrefix(q),
 		TotalItems:  p.TotalItems,
 		CurrentPage: p.CurrentPage,
 		TotalPages:  p.TotalPages,
@@ -104,3 +108,69 @@ func (p *Pagination) Pages() []int {
 
 	return pages
 }
+
+type pageSizeLink struct {
+	Size    int
+	Href    string
+	Current bool
+}
+
+// PageSizeLinks links to the first page at each size, as the current page may
+// not exist at another size.
+func (p *Pagination) PageSizeLinks() []pageSizeLink {
+	links := make([]pageSizeLink, len(pageSizes))
+	for i, size := range pageSizes {
+		links[i] = pageSizeLink{
+			Size:    size,
+			Href:    p.Query + "page-size=" + strconv.Itoa(size),
+			Current: size == p.PageSize,
+		}
+	}
+
+	return links
+}
+
+// getPageSize returns 0 when no size has been chosen.
+func getPageSize(w http.ResponseWriter, r *http.Request, prefix string) (int, error) {
+	if v := r.FormValue("page-size"); v != "" {
+		size, err := strconv.Atoi(v)
+		if err != nil || !slices.Contains(pageSizes, size) {
+			return 0, StatusError(http.StatusBadRequest)
+		}
+
+		http.SetCookie(w, &http.Cookie{
+			Name:     pageSizeCookie,
+			Value:    v,
+			Path:     prefix + "/",
+			MaxAge:   365 * 24 * 60 * 60,
+			HttpOnly: true,
+			SameSite: http.SameSiteLaxMode,
+		})
+
+		return size, nil
+	}
+
+	if cookie, err := r.Cookie(pageSizeCookie); err == nil {
+		if size, err := strconv.Atoi(cookie.Value); err == nil && slices.Contains(pageSizes, size) {
+			return size, nil
+		}
+	}
+
+	return 0, nil
+}
+
+func withPageSize(criteria sirius.Criteria, size int) sirius.Criteria {
+	if size == 0 {
+		return criteria
+	}
+
+	return criteria.Limit(size)
+}
+
+func queryPrefix(q string) string {
+	if q == "" {
+		return "?"
+	}
+
+	return "?" + q + "&"
+}
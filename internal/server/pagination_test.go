@@ -2,6 +2,8 @@ package server
 
 import (
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/ministryofjustice/opg-sirius-lpa-dashboard/internal/sirius"
@@ -149,3 +151,81 @@ func TestPaginationWithQuery(t *testing.T) {
 
 	assert.Equal("?this-is-here&", pagination.Query)
 }
+
+func TestPaginationPageSizeLinks(t *testing.T) {
+	pagination := newPaginationWithQuery(&sirius.Pagination{PageSize: 50}, "sort=uId%3Aasc")
+
+	assert.Equal(t, []pageSizeLink{
+		{Size: 25, Href: "?sort=uId%3Aasc&page-size=25"},
+		{Size: 50, Href: "?sort=uId%3Aasc&page-size=50", Current: true},
+		{Size: 100, Href: "?sort=uId%3Aasc&page-size=100"},
+	}, pagination.PageSizeLinks())
+}
+
+func TestGetPageSize(t *testing.T) {
+	testCases := map[string]struct {
+		URL       string
+		Cookie    string
+		Size      int
+		SetCookie string
+		Error     error
+	}{
+		"None": {
+			URL: "/path",
+		},
+		"Query": {
+			URL:       "/path?page-size=50",
+			Size:      50,
+			SetCookie: "50",
+		},
+		"QueryOverridesCookie": {
+			URL:       "/path?page-size=100",
+			Cookie:    "25",
+			Size:      100,
+			SetCookie: "100",
+		},
+		"QueryNotAllowed": {
+			URL:   "/path?page-size=30",
+			Error: StatusError(http.StatusBadRequest),
+		},
+		"QueryNotNumber": {
+			URL:   "/path?page-size=all",
+			Error: StatusError(http.StatusBadRequest),
+		},
+		"Cookie": {
+			URL:    "/path",
+			Cookie: "25",
+			Size:   25,
+		},
+		"CookieNotAllowed": {
+			URL:    "/path",
+			Cookie: "1000",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			w := httptest.NewRecorder()
+			r, _ := http.NewRequest(http.MethodGet, tc.URL, nil)
+			if tc.Cookie != "" {
+				r.AddCookie(&http.Cookie{Name: pageSizeCookie, Value: tc.Cookie})
+			}
+
+			size, err := getPageSize(w, r, "/prefix")
+			assert.Equal(tc.Error, err)
+			assert.Equal(tc.Size, size)
+
+			cookies := w.Result().Cookies()
+			if tc.SetCookie == "" {
+				assert.Empty(cookies)
+			} else if assert.Len(cookies, 1) {
+				assert.Equal(pageSizeCookie, cookies[0].Name)
+				assert.Equal(tc.SetCookie, cookies[0].Value)
+				assert.Equal("/prefix/", cookies[0].Path)
+				assert.True(cookies[0].HttpOnly)
+			}
+		})
+	}
+}
@@ -2,6 +2,7 @@ package server
 
 import (
 	"net/http"
+	"time"
 
 	"github.com/ministryofjustice/opg-sirius-lpa-dashboard/internal/sirius"
 )
@@ -13,15 +14,19 @@ type PendingCasesClient interface {
 }
 
 type pendingCasesVars struct {
-	Cases           []sirius.Case
-	Pagination      *Pagination
-	HasWorkableCase bool
-	CanRequestCase  bool
-	IsManager       bool
-	XSRFToken       string
+	Cases           []sirius.Case `json:"cases"`
+	Pagination      *Pagination   `json:"pagination"`
+	HasWorkableCase bool          `json:"hasWorkableCase"`
+	CanRequestCase  bool          `json:"canRequestCase"`
+	IsManager       bool          `json:"isManager"`
+	XSRFToken       string        `json:"-"`
+	UndoCases       []int         `json:"undoCases"`
+	WorkedAt        string        `json:"workedAt"`
+	UndoSignature   string        `json:"undoSignature"`
+	Sort            Sort          `json:"sort"`
 }
 
-func pendingCases(client PendingCasesClient, tmpl Template) Handler {
+func pendingCases(client PendingCasesClient, tmpl Template, prefix string, undo undoSigner) Handler {
 	return func(w http.ResponseWriter, r *http.Request) error {
 		if r.Method != http.MethodGet {
 			return StatusError(http.StatusMethodNotAllowed)
@@ -34,7 +39,22 @@ func pendingCases(client PendingCasesClient, tmpl Template) Handler {
 			return err
 		}
 
-		criteria := sirius.Criteria{}.Filter("status", "Pending").Page(getPage(r)).Sort("workedDate", sirius.Descending).Sort("receiptDate", sirius.Ascending)
+		chosen, err := caseSortSchema.Parse(r.URL.Query())
+		if err != nil {
+			return err
+		}
+
+		pageSize, err := getPageSize(w, r, prefix)
+		if err != nil {
+			return err
+		}
+
+		criteria := chosen
+		if !criteria.IsSorted() {
+			criteria = criteria.Sort("workedDate", sirius.Descending).Sort("receiptDate", sirius.Ascending)
+		}
+
+		criteria = withPageSize(criteria.Filter("status", "Pending").Page(getPage(r)), pageSize)
 		myCases, pagination, err := client.CasesByAssignee(ctx, myDetails.ID, criteria)
 
 		if err != nil {
@@ -48,11 +68,18 @@ func pendingCases(client PendingCasesClient, tmpl Template) Handler {
 
 		vars := pendingCasesVars{
 			Cases:           myCases,
-			Pagination:      newPagination(pagination),
+			Pagination:      newPaginationWithQuery(pagination, caseSortSchema.Encode(chosen).Encode()),
 			HasWorkableCase: hasWorkableCase,
 			CanRequestCase:  myDetails.HasRole("Self Allocation User"),
 			IsManager:       myDetails.HasRole("Manager"),
 			XSRFToken:       ctx.XSRFToken,
+			Sort:            newSort(caseSortSchema, chosen, criteria),
+		}
+
+		if ids, ok := undo.cases(r.URL.Query(), time.Now()); ok {
+			vars.UndoCases = ids
+			vars.WorkedAt = r.URL.Query().Get("workedAt")
+			vars.UndoSignature = r.URL.Query().Get("undo")
 		}
 
 		return tmpl.ExecuteTemplate(w, "page", vars)
@@ -4,7 +4,9 @@ import (
 	"errors"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
 	"testing"
+	"time"
 
 	"github.com/ministryofjustice/opg-sirius-lpa-dashboard/internal/sirius"
 	"github.com/stretchr/testify/assert"
@@ -81,7 +83,7 @@ func TestGetPendingCases(t *testing.T) {
 	w := httptest.NewRecorder()
 	r, _ := http.NewRequest("GET", "/path", nil)
 
-	err := pendingCases(client, template)(w, r)
+	err := pendingCases(client, template, "", testUndo)(w, r)
 	assert.Nil(err)
 
 	assert.Equal(1, client.myDetails.count)
@@ -98,9 +100,50 @@ func TestGetPendingCases(t *testing.T) {
 		Cases:           client.casesByAssignee.data,
 		Pagination:      newPagination(client.casesByAssignee.pagination),
 		HasWorkableCase: true,
+		Sort:            Sort{Field: "workedDate", Order: "desc"},
 	}, template.lastVars)
 }
 
+func TestGetPendingCasesUndo(t *testing.T) {
+	assert := assert.New(t)
+
+	client := &mockPendingCasesClient{}
+	template := &mockTemplate{}
+
+	workedAt := time.Now().Unix()
+	query := testUndo.query([]int{12, 34}, workedAt)
+
+	w := httptest.NewRecorder()
+	r, _ := http.NewRequest("GET", "/path?"+query.Encode(), nil)
+
+	err := pendingCases(client, template, "", testUndo)(w, r)
+	assert.Nil(err)
+
+	vars := template.lastVars.(pendingCasesVars)
+	assert.Equal([]int{12, 34}, vars.UndoCases)
+	assert.Equal(strconv.FormatInt(workedAt, 10), vars.WorkedAt)
+	assert.Equal(query.Get("undo"), vars.UndoSignature)
+}
+
+func TestGetPendingCasesUndoExpired(t *testing.T) {
+	assert := assert.New(t)
+
+	client := &mockPendingCasesClient{}
+	template := &mockTemplate{}
+
+	query := testUndo.query([]int{12}, time.Now().Add(-undoWindow-time.Minute).Unix())
+
+	w := httptest.NewRecorder()
+	r, _ := http.NewRequest("GET", "/path?"+query.Encode(), nil)
+
+	err := pendingCases(client, template, "", testUndo)(w, r)
+	assert.Nil(err)
+
+	vars := template.lastVars.(pendingCasesVars)
+	assert.Nil(vars.UndoCases)
+	assert.Equal("", vars.WorkedAt)
+}
+
 func TestGetPendingCasesPage(t *testing.T) {
 	assert := assert.New(t)
 
@@ -121,7 +164,7 @@ func TestGetPendingCasesPage(t *testing.T) {
 	w := httptest.NewRecorder()
 	r, _ := http.NewRequest("GET", "/path?page=4", nil)
 
-	err := pendingCases(client, template)(w, r)
+	err := pendingCases(client, template, "", testUndo)(w, r)
 	assert.Nil(err)
 
 	assert.Equal(1, client.myDetails.count)
@@ -138,9 +181,44 @@ func TestGetPendingCasesPage(t *testing.T) {
 		CanRequestCase: true,
 		Cases:          client.casesByAssignee.data,
 		Pagination:     newPagination(client.casesByAssignee.pagination),
+		Sort:           Sort{Field: "workedDate", Order: "desc"},
 	}, template.lastVars)
 }
 
+func TestGetPendingCasesPageSize(t *testing.T) {
+	assert := assert.New(t)
+
+	client := &mockPendingCasesClient{}
+	client.myDetails.data = sirius.MyDetails{ID: 14}
+	client.casesByAssignee.pagination = &sirius.Pagination{}
+	template := &mockTemplate{}
+
+	w := httptest.NewRecorder()
+	r, _ := http.NewRequest("GET", "/path?page=2", nil)
+	r.AddCookie(&http.Cookie{Name: pageSizeCookie, Value: "50"})
+
+	err := pendingCases(client, template, "", testUndo)(w, r)
+	assert.Nil(err)
+
+	assert.Equal(sirius.Criteria{}.Filter("status", "Pending").Page(2).Limit(50).Sort("workedDate", sirius.Descending).Sort("receiptDate", sirius.Ascending), client.casesByAssignee.lastCriteria)
+}
+
+func TestGetPendingCasesBadPageSize(t *testing.T) {
+	assert := assert.New(t)
+
+	client := &mockPendingCasesClient{}
+	template := &mockTemplate{}
+
+	w := httptest.NewRecorder()
+	r, _ := http.NewRequest("GET", "/path?page-size=7", nil)
+
+	err := pendingCases(client, template, "", testUndo)(w, r)
+	assert.Equal(StatusError(http.StatusBadRequest), err)
+
+	assert.Equal(0, client.casesByAssignee.count)
+	assert.Equal(0, template.count)
+}
+
 func TestGetPendingCasesMyDetailsError(t *testing.T) {
 	assert := assert.New(t)
 
@@ -153,7 +231,7 @@ func TestGetPendingCasesMyDetailsError(t *testing.T) {
 	w := httptest.NewRecorder()
 	r, _ := http.NewRequest("GET", "/path", nil)
 
-	err := pendingCases(client, template)(w, r)
+	err := pendingCases(client, template, "", testUndo)(w, r)
 	assert.Equal(expectedError, err)
 
 	assert.Equal(1, client.myDetails.count)
@@ -177,7 +255,7 @@ func TestGetPendingCasesQueryError(t *testing.T) {
 	w := httptest.NewRecorder()
 	r, _ := http.NewRequest("GET", "/path", nil)
 
-	err := pendingCases(client, template)(w, r)
+	err := pendingCases(client, template, "", testUndo)(w, r)
 	assert.Equal(expectedError, err)
 
 	assert.Equal(1, client.myDetails.count)
@@ -198,7 +276,7 @@ func TestBadMethodPendingCases(t *testing.T) {
 	w := httptest.NewRecorder()
 	r, _ := http.NewRequest("DELETE", "/path", nil)
 
-	err := pendingCases(client, template)(w, r)
+	err := pendingCases(client, template, "", testUndo)(w, r)
 
 	assert.Equal(StatusError(http.StatusMethodNotAllowed), err)
 
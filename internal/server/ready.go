@@ -0,0 +1,29 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/ministryofjustice/opg-sirius-lpa-dashboard/internal/sirius"
+)
+
+type ReadyClient interface {
+	CircuitState() sirius.CircuitState
+}
+
+type readyResponse struct {
+	Sirius sirius.CircuitState `json:"sirius"`
+}
+
+func ready(client ReadyClient) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		state := client.CircuitState()
+
+		w.Header().Set("Content-Type", "application/json")
+		if state == sirius.CircuitOpen {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+
+		_ = json.NewEncoder(w).Encode(readyResponse{Sirius: state})
+	}
+}
@@ -0,0 +1,42 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ministryofjustice/opg-sirius-lpa-dashboard/internal/sirius"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockReadyClient struct {
+	state sirius.CircuitState
+}
+
+func (m *mockReadyClient) CircuitState() sirius.CircuitState {
+	return m.state
+}
+
+func TestReady(t *testing.T) {
+	testCases := map[sirius.CircuitState]int{
+		sirius.CircuitClosed:   http.StatusOK,
+		sirius.CircuitHalfOpen: http.StatusOK,
+		sirius.CircuitOpen:     http.StatusServiceUnavailable,
+	}
+
+	for state, code := range testCases {
+		t.Run(string(state), func(t *testing.T) {
+			assert := assert.New(t)
+
+			w := httptest.NewRecorder()
+			r, _ := http.NewRequest("GET", "/ready", nil)
+
+			ready(&mockReadyClient{state: state})(w, r)
+
+			resp := w.Result()
+			assert.Equal(code, resp.StatusCode)
+			assert.Equal("application/json", resp.Header.Get("Content-Type"))
+			assert.JSONEq(`{"sirius":"`+string(state)+`"}`, w.Body.String())
+		})
+	}
+}
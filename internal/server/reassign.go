@@ -12,19 +12,58 @@ type ReassignClient interface {
 	User(sirius.Context, int) (sirius.Assignee, error)
 	UserByEmail(sirius.Context, string) (sirius.User, error)
 	Team(sirius.Context, int) (sirius.Team, error)
-	Assign(sirius.Context, []int, int) error
+	Assign(sirius.Context, []int, int) ([]sirius.AssignResult, error)
 }
 
+type ReassignTasksClient interface {
+	MyDetails(sirius.Context) (sirius.MyDetails, error)
+	User(sirius.Context, int) (sirius.Assignee, error)
+	UserByEmail(sirius.Context, string) (sirius.User, error)
+	Team(sirius.Context, int) (sirius.Team, error)
+	AssignTasks(sirius.Context, []int, int) ([]sirius.AssignResult, error)
+}
+
+// reassignLookupClient is the part of ReassignClient and ReassignTasksClient
+// needed to find who things can be reassigned to.
+type reassignLookupClient interface {
+	MyDetails(sirius.Context) (sirius.MyDetails, error)
+	User(sirius.Context, int) (sirius.Assignee, error)
+	UserByEmail(sirius.Context, string) (sirius.User, error)
+	Team(sirius.Context, int) (sirius.Team, error)
+}
+
+type assignFunc func(sirius.Context, []int, int) ([]sirius.AssignResult, error)
+
 type reassignVars struct {
-	XSRFToken   string
-	Selected    []int
-	Assignee    sirius.Assignee
-	TeamMembers []sirius.TeamMember
-	Success     bool
-	AssignedTo  sirius.Assignee
+	Tasks         bool                `json:"tasks"`
+	XSRFToken     string              `json:"-"`
+	Selected      []int               `json:"selected"`
+	Assignee      sirius.Assignee     `json:"assignee"`
+	TeamMembers   []sirius.TeamMember `json:"teamMembers"`
+	Success       bool                `json:"success"`
+	AssignedTo    sirius.Assignee     `json:"assignedTo"`
+	Reassigned    []int               `json:"reassigned"`
+	NotReassigned []caseFailure       `json:"notReassigned"`
 }
 
 func reassign(client ReassignClient, tmpl Template) Handler {
+	return reassignItems(client, tmpl, false, func(ctx sirius.Context, cases []int, assignee int) ([]sirius.AssignResult, error) {
+		return client.Assign(ctx, cases, assignee)
+	})
+}
+
+func reassignTasks(client ReassignTasksClient, tmpl Template) Handler {
+	return reassignItems(client, tmpl, true, func(ctx sirius.Context, tasks []int, assignee int) ([]sirius.AssignResult, error) {
+		return client.AssignTasks(ctx, tasks, assignee)
+	})
+}
+
+func reassignItems(client reassignLookupClient, tmpl Template, tasks bool, assign assignFunc) Handler {
+	failureReason := "Sirius could not reassign this case"
+	if tasks {
+		failureReason = "Sirius could not reassign this task"
+	}
+
 	getAssignee := func(ctx sirius.Context, id string) (sirius.Assignee, error) {
 		assigneeID, err := strconv.Atoi(id)
 		if err != nil {
@@ -64,16 +103,20 @@ func reassign(client ReassignClient, tmpl Template) Handler {
 			selected = append(selected, i)
 		}
 
-		team, err := client.Team(ctx, assignee.Teams[0].ID)
-		if err != nil {
-			return err
+		vars := reassignVars{
+			Tasks:     tasks,
+			XSRFToken: ctx.XSRFToken,
+			Selected:  selected,
+			Assignee:  assignee,
 		}
 
-		vars := reassignVars{
-			XSRFToken:   ctx.XSRFToken,
-			Selected:    selected,
-			Assignee:    assignee,
-			TeamMembers: team.Members,
+		if len(assignee.Teams) > 0 {
+			team, err := client.Team(ctx, assignee.Teams[0].ID)
+			if err != nil {
+				return err
+			}
+
+			vars.TeamMembers = team.Members
 		}
 
 		if r.Method == http.MethodPost {
@@ -97,10 +140,19 @@ func reassign(client ReassignClient, tmpl Template) Handler {
 				return StatusError(http.StatusBadRequest)
 			}
 
-			if err := client.Assign(ctx, selected, reassignTo.ID); err != nil {
+			results, err := assign(ctx, selected, reassignTo.ID)
+			if err != nil {
 				return err
 			}
 
+			for _, result := range results {
+				if result.Err == nil {
+					vars.Reassigned = append(vars.Reassigned, result.ID)
+				} else {
+					vars.NotReassigned = append(vars.NotReassigned, newCaseFailure(result.ID, result.Err, failureReason))
+				}
+			}
+
 			vars.Success = true
 			vars.AssignedTo = reassignTo
 		}
@@ -44,6 +44,15 @@ type mockReassignClient struct {
 		lastCtx      sirius.Context
 		lastCases    []int
 		lastAssignee int
+		data         []sirius.AssignResult
+		err          error
+	}
+	assignTasks struct {
+		count        int
+		lastCtx      sirius.Context
+		lastTasks    []int
+		lastAssignee int
+		data         []sirius.AssignResult
 		err          error
 	}
 }
@@ -81,13 +90,22 @@ func (m *mockReassignClient) Team(ctx sirius.Context, id int) (sirius.Team, erro
 	return m.team.data, m.team.err
 }
 
-func (m *mockReassignClient) Assign(ctx sirius.Context, cases []int, assignee int) error {
+func (m *mockReassignClient) AssignTasks(ctx sirius.Context, tasks []int, assignee int) ([]sirius.AssignResult, error) {
+	m.assignTasks.count += 1
+	m.assignTasks.lastCtx = ctx
+	m.assignTasks.lastTasks = tasks
+	m.assignTasks.lastAssignee = assignee
+
+	return m.assignTasks.data, m.assignTasks.err
+}
+
+func (m *mockReassignClient) Assign(ctx sirius.Context, cases []int, assignee int) ([]sirius.AssignResult, error) {
 	m.assign.count += 1
 	m.assign.lastCtx = ctx
 	m.assign.lastCases = cases
 	m.assign.lastAssignee = assignee
 
-	return m.assign.err
+	return m.assign.data, m.assign.err
 }
 
 func TestGetReassign(t *testing.T) {
@@ -144,6 +162,37 @@ func TestGetReassign(t *testing.T) {
 	}, template.lastVars)
 }
 
+func TestGetReassignAssigneeWithoutTeam(t *testing.T) {
+	assert := assert.New(t)
+
+	client := &mockReassignClient{}
+	client.myDetails.data = sirius.MyDetails{
+		ID:    14,
+		Roles: []string{"Manager"},
+	}
+	client.user.data = []sirius.Assignee{{
+		ID:          47,
+		DisplayName: "Central Pot",
+	}}
+	client.user.err = []error{nil}
+	template := &mockTemplate{}
+
+	w := httptest.NewRecorder()
+	r, _ := http.NewRequest("GET", "/path?selected=1&assignee=47", nil)
+
+	err := reassign(client, template)(w, r)
+	assert.Nil(err)
+
+	assert.Equal(0, client.team.count)
+
+	assert.Equal(1, template.count)
+	assert.Equal(reassignVars{
+		XSRFToken: getContext(r).XSRFToken,
+		Selected:  []int{1},
+		Assignee:  client.user.data[0],
+	}, template.lastVars)
+}
+
 func TestGetReassignNotManager(t *testing.T) {
 	assert := assert.New(t)
 
@@ -292,6 +341,7 @@ func TestPostReassignToCentralPot(t *testing.T) {
 	client.userByEmail.data = sirius.User{
 		ID: 50,
 	}
+	client.assign.data = []sirius.AssignResult{{ID: 1}, {ID: 4}}
 	template := &mockTemplate{}
 
 	w := httptest.NewRecorder()
@@ -333,6 +383,7 @@ func TestPostReassignToCentralPot(t *testing.T) {
 			ID:          50,
 			DisplayName: "Central Pot",
 		},
+		Reassigned: []int{1, 4},
 	}, template.lastVars)
 }
 
@@ -410,6 +461,7 @@ func TestPostReassignToUser(t *testing.T) {
 			},
 		},
 	}
+	client.assign.data = []sirius.AssignResult{{ID: 1}, {ID: 4}}
 	template := &mockTemplate{}
 
 	w := httptest.NewRecorder()
@@ -446,6 +498,122 @@ func TestPostReassignToUser(t *testing.T) {
 		TeamMembers: client.team.data.Members,
 		Success:     true,
 		AssignedTo:  client.user.data[1],
+		Reassigned:  []int{1, 4},
+	}, template.lastVars)
+}
+
+func TestPostReassignTasks(t *testing.T) {
+	assert := assert.New(t)
+
+	client := &mockReassignClient{}
+	client.myDetails.data = sirius.MyDetails{
+		ID:    14,
+		Roles: []string{"Manager"},
+	}
+	client.user.data = []sirius.Assignee{{
+		ID:          47,
+		DisplayName: "some person",
+		Teams: []sirius.Team{{
+			ID: 439,
+		}},
+	}}
+	client.user.err = []error{nil}
+	client.team.data = sirius.Team{ID: 439}
+	client.userByEmail.data = sirius.User{ID: 50}
+	client.assignTasks.data = []sirius.AssignResult{
+		{ID: 36},
+		{ID: 37, Err: errors.New("oops")},
+	}
+	template := &mockTemplate{}
+
+	w := httptest.NewRecorder()
+	r, _ := http.NewRequest("POST", "/path", strings.NewReader("selected=36&selected=37&assignee=47&reassign=central-pot"))
+	r.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+
+	err := reassignTasks(client, template)(w, r)
+	assert.Nil(err)
+
+	assert.Equal(0, client.assign.count)
+	assert.Equal(1, client.assignTasks.count)
+	assert.Equal(getContext(r), client.assignTasks.lastCtx)
+	assert.Equal([]int{36, 37}, client.assignTasks.lastTasks)
+	assert.Equal(50, client.assignTasks.lastAssignee)
+
+	assert.Equal(reassignVars{
+		Tasks:       true,
+		XSRFToken:   getContext(r).XSRFToken,
+		Selected:    []int{36, 37},
+		Assignee:    client.user.data[0],
+		TeamMembers: client.team.data.Members,
+		Success:     true,
+		AssignedTo: sirius.Assignee{
+			ID:          50,
+			DisplayName: "Central Pot",
+		},
+		Reassigned: []int{36},
+		NotReassigned: []caseFailure{
+			{ID: 37, Reason: "Sirius could not reassign this task"},
+		},
+	}, template.lastVars)
+}
+
+func TestPostReassignPartialFailure(t *testing.T) {
+	assert := assert.New(t)
+
+	client := &mockReassignClient{}
+	client.myDetails.data = sirius.MyDetails{
+		ID:    14,
+		Roles: []string{"Manager"},
+	}
+	client.user.data = []sirius.Assignee{
+		{
+			ID:          47,
+			DisplayName: "some person",
+			Teams: []sirius.Team{{
+				ID: 439,
+			}},
+		},
+		{
+			ID:          99,
+			DisplayName: "Assigned to user",
+		},
+	}
+	client.user.err = []error{nil, nil}
+	client.team.data = sirius.Team{ID: 439}
+	client.assign.data = []sirius.AssignResult{
+		{ID: 1},
+		{ID: 4, Err: &sirius.StatusError{
+			Code:    http.StatusBadRequest,
+			Problem: &sirius.Problem{Title: "Bad request", Detail: "Case is closed"},
+		}},
+		{ID: 6, Err: &sirius.StatusError{
+			Code:    http.StatusBadRequest,
+			Problem: &sirius.Problem{Title: "Bad request"},
+		}},
+		{ID: 7, Err: errors.New("connection reset")},
+	}
+	template := &mockTemplate{}
+
+	w := httptest.NewRecorder()
+	r, _ := http.NewRequest("POST", "/path", strings.NewReader("selected=1&selected=4&selected=6&selected=7&assignee=47&reassign=user&caseworker=99"))
+	r.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+
+	err := reassign(client, template)(w, r)
+	assert.Nil(err)
+
+	assert.Equal(reassignVars{
+		XSRFToken:   getContext(r).XSRFToken,
+		Selected:    []int{1, 4, 6, 7},
+		Assignee:    client.user.data[0],
+		TeamMembers: client.team.data.Members,
+		Success:     true,
+		AssignedTo:  client.user.data[1],
+		Reassigned:  []int{1},
+		NotReassigned: []caseFailure{
+			{ID: 4, Reason: "Bad request"},
+			{ID: 6, Reason: "Bad request"},
+			{ID: 7, Reason: "Sirius could not reassign this case"},
+		},
 	}, template.lastVars)
 }
 
@@ -605,3 +773,13 @@ func TestBadMethodReassign(t *testing.T) {
 	err := reassign(nil, nil)(w, r)
 	assert.Equal(StatusError(http.StatusMethodNotAllowed), err)
 }
+
+func TestBadMethodReassignTasks(t *testing.T) {
+	assert := assert.New(t)
+
+	w := httptest.NewRecorder()
+	r, _ := http.NewRequest("DELETE", "/path", nil)
+
+	err := reassignTasks(nil, nil)(w, r)
+	assert.Equal(StatusError(http.StatusMethodNotAllowed), err)
+}
@@ -0,0 +1,69 @@
+package server
+
+import (
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/ministryofjustice/opg-sirius-lpa-dashboard/internal/sirius"
+)
+
+// uidPattern matches a case UID however it has been pasted, with or without
+// the usual dashes or spaces between each group of four digits.
+var uidPattern = regexp.MustCompile(`^(\d{4})[- ]?(\d{4})[- ]?(\d{4})$`)
+
+type SearchCasesClient interface {
+	MyDetails(sirius.Context) (sirius.MyDetails, error)
+	SearchCases(sirius.Context, string) ([]sirius.Case, error)
+}
+
+type searchCasesVars struct {
+	Query     string        `json:"query"`
+	Searched  bool          `json:"searched"`
+	Cases     []sirius.Case `json:"cases"`
+	IsManager bool          `json:"isManager"`
+	XSRFToken string        `json:"-"`
+}
+
+func searchCases(client SearchCasesClient, tmpl Template) Handler {
+	return func(w http.ResponseWriter, r *http.Request) error {
+		if r.Method != http.MethodGet {
+			return StatusError(http.StatusMethodNotAllowed)
+		}
+
+		ctx := getContext(r)
+
+		myDetails, err := client.MyDetails(ctx)
+		if err != nil {
+			return err
+		}
+
+		vars := searchCasesVars{
+			Query:     strings.TrimSpace(r.FormValue("q")),
+			IsManager: myDetails.IsManager(),
+			XSRFToken: ctx.XSRFToken,
+		}
+
+		if vars.Query != "" {
+			cases, err := client.SearchCases(ctx, normaliseSearchTerm(vars.Query))
+			if err != nil {
+				return err
+			}
+
+			vars.Searched = true
+			vars.Cases = cases
+		}
+
+		return tmpl.ExecuteTemplate(w, "page", vars)
+	}
+}
+
+// normaliseSearchTerm rewrites a UID into the 7000-0000-0000 form Sirius
+// stores, leaving any other term unchanged.
+func normaliseSearchTerm(term string) string {
+	if m := uidPattern.FindStringSubmatch(term); m != nil {
+		return m[1] + "-" + m[2] + "-" + m[3]
+	}
+
+	return term
+}
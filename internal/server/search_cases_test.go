@@ -0,0 +1,161 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ministryofjustice/opg-sirius-lpa-dashboard/internal/sirius"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockSearchCasesClient struct {
+	myDetails struct {
+		count   int
+		lastCtx sirius.Context
+		data    sirius.MyDetails
+		err     error
+	}
+	searchCases struct {
+		count    int
+		lastCtx  sirius.Context
+		lastTerm string
+		data     []sirius.Case
+		err      error
+	}
+}
+
+func (m *mockSearchCasesClient) MyDetails(ctx sirius.Context) (sirius.MyDetails, error) {
+	m.myDetails.count += 1
+	m.myDetails.lastCtx = ctx
+
+	return m.myDetails.data, m.myDetails.err
+}
+
+func (m *mockSearchCasesClient) SearchCases(ctx sirius.Context, term string) ([]sirius.Case, error) {
+	m.searchCases.count += 1
+	m.searchCases.lastCtx = ctx
+	m.searchCases.lastTerm = term
+
+	return m.searchCases.data, m.searchCases.err
+}
+
+func TestGetSearchCases(t *testing.T) {
+	assert := assert.New(t)
+
+	client := &mockSearchCasesClient{}
+	client.myDetails.data = sirius.MyDetails{Roles: []string{"Manager"}}
+	client.searchCases.data = []sirius.Case{{
+		ID:       78,
+		Uid:      "7000-8548-8461",
+		Assignee: sirius.Assignee{ID: 47, DisplayName: "John Smith"},
+	}}
+	template := &mockTemplate{}
+
+	w := httptest.NewRecorder()
+	r, _ := http.NewRequest("GET", "/search?q=+Kurkjian+", nil)
+
+	err := searchCases(client, template)(w, r)
+	assert.Nil(err)
+
+	assert.Equal(1, client.searchCases.count)
+	assert.Equal(getContext(r), client.searchCases.lastCtx)
+	assert.Equal("Kurkjian", client.searchCases.lastTerm)
+
+	assert.Equal(1, template.count)
+	assert.Equal("page", template.lastName)
+	assert.Equal(searchCasesVars{
+		Query:     "Kurkjian",
+		Searched:  true,
+		Cases:     client.searchCases.data,
+		IsManager: true,
+	}, template.lastVars)
+}
+
+func TestGetSearchCasesNormalisesUid(t *testing.T) {
+	for _, q := range []string{"700085488461", "7000 8548 8461", "7000-8548-8461"} {
+		t.Run(q, func(t *testing.T) {
+			client := &mockSearchCasesClient{}
+			template := &mockTemplate{}
+
+			w := httptest.NewRecorder()
+			r, _ := http.NewRequest("GET", "/search?q="+q, nil)
+
+			err := searchCases(client, template)(w, r)
+			assert.Nil(t, err)
+			assert.Equal(t, "7000-8548-8461", client.searchCases.lastTerm)
+		})
+	}
+}
+
+func TestGetSearchCasesNoQuery(t *testing.T) {
+	assert := assert.New(t)
+
+	client := &mockSearchCasesClient{}
+	client.myDetails.data = sirius.MyDetails{Roles: []string{"Case Worker"}}
+	template := &mockTemplate{}
+
+	w := httptest.NewRecorder()
+	r, _ := http.NewRequest("GET", "/search?q=++", nil)
+
+	err := searchCases(client, template)(w, r)
+	assert.Nil(err)
+
+	assert.Equal(0, client.searchCases.count)
+	assert.Equal(searchCasesVars{}, template.lastVars)
+}
+
+func TestGetSearchCasesMyDetailsError(t *testing.T) {
+	assert := assert.New(t)
+
+	expectedError := errors.New("oops")
+
+	client := &mockSearchCasesClient{}
+	client.myDetails.err = expectedError
+	template := &mockTemplate{}
+
+	w := httptest.NewRecorder()
+	r, _ := http.NewRequest("GET", "/search?q=john", nil)
+
+	err := searchCases(client, template)(w, r)
+	assert.Equal(expectedError, err)
+
+	assert.Equal(0, client.searchCases.count)
+	assert.Equal(0, template.count)
+}
+
+func TestGetSearchCasesQueryError(t *testing.T) {
+	assert := assert.New(t)
+
+	expectedError := errors.New("oops")
+
+	client := &mockSearchCasesClient{}
+	client.searchCases.err = expectedError
+	template := &mockTemplate{}
+
+	w := httptest.NewRecorder()
+	r, _ := http.NewRequest("GET", "/search?q=john", nil)
+
+	err := searchCases(client, template)(w, r)
+	assert.Equal(expectedError, err)
+
+	assert.Equal(1, client.searchCases.count)
+	assert.Equal(0, template.count)
+}
+
+func TestBadMethodSearchCases(t *testing.T) {
+	assert := assert.New(t)
+
+	client := &mockSearchCasesClient{}
+	template := &mockTemplate{}
+
+	w := httptest.NewRecorder()
+	r, _ := http.NewRequest("POST", "/search", nil)
+
+	err := searchCases(client, template)(w, r)
+	assert.Equal(StatusError(http.StatusMethodNotAllowed), err)
+
+	assert.Equal(0, client.searchCases.count)
+	assert.Equal(0, template.count)
+}
@@ -0,0 +1,67 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/ministryofjustice/opg-sirius-lpa-dashboard/internal/sirius"
+)
+
+// searchUsersMinLength is the shortest term that will be sent to Sirius, as
+// shorter terms match too many users to be useful.
+const searchUsersMinLength = 3
+
+type SearchUsersClient interface {
+	MyDetails(sirius.Context) (sirius.MyDetails, error)
+	SearchUsers(sirius.Context, string) ([]sirius.Assignee, error)
+}
+
+type searchUsersResult struct {
+	ID          int    `json:"id"`
+	DisplayName string `json:"displayName"`
+	Team        string `json:"team,omitempty"`
+}
+
+func searchUsers(client SearchUsersClient) Handler {
+	return func(w http.ResponseWriter, r *http.Request) error {
+		if r.Method != http.MethodGet {
+			return StatusError(http.StatusMethodNotAllowed)
+		}
+
+		ctx := getContext(r)
+
+		myDetails, err := client.MyDetails(ctx)
+		if err != nil {
+			return err
+		}
+
+		if !myDetails.IsManager() {
+			return StatusError(http.StatusForbidden)
+		}
+
+		results := []searchUsersResult{}
+
+		if term := strings.TrimSpace(r.FormValue("q")); len(term) >= searchUsersMinLength {
+			users, err := client.SearchUsers(ctx, term)
+			if err != nil {
+				return err
+			}
+
+			for _, user := range users {
+				result := searchUsersResult{
+					ID:          user.ID,
+					DisplayName: user.DisplayName,
+				}
+				if len(user.Teams) > 0 {
+					result.Team = user.Teams[0].DisplayName
+				}
+
+				results = append(results, result)
+			}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		return json.NewEncoder(w).Encode(results)
+	}
+}
@@ -0,0 +1,124 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ministryofjustice/opg-sirius-lpa-dashboard/internal/sirius"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockSearchUsersClient struct {
+	myDetails struct {
+		count   int
+		lastCtx sirius.Context
+		data    sirius.MyDetails
+		err     error
+	}
+	searchUsers struct {
+		count    int
+		lastCtx  sirius.Context
+		lastTerm string
+		data     []sirius.Assignee
+		err      error
+	}
+}
+
+func (m *mockSearchUsersClient) MyDetails(ctx sirius.Context) (sirius.MyDetails, error) {
+	m.myDetails.count += 1
+	m.myDetails.lastCtx = ctx
+
+	return m.myDetails.data, m.myDetails.err
+}
+
+func (m *mockSearchUsersClient) SearchUsers(ctx sirius.Context, term string) ([]sirius.Assignee, error) {
+	m.searchUsers.count += 1
+	m.searchUsers.lastCtx = ctx
+	m.searchUsers.lastTerm = term
+
+	return m.searchUsers.data, m.searchUsers.err
+}
+
+func TestGetSearchUsers(t *testing.T) {
+	assert := assert.New(t)
+
+	client := &mockSearchUsersClient{}
+	client.myDetails.data = sirius.MyDetails{Roles: []string{"Manager"}}
+	client.searchUsers.data = []sirius.Assignee{
+		{ID: 47, DisplayName: "John Smith", Teams: []sirius.Team{{ID: 1, DisplayName: "Casework Team 1"}}},
+		{ID: 48, DisplayName: "Johnny Teamless"},
+	}
+
+	w := httptest.NewRecorder()
+	r, _ := http.NewRequest("GET", "/search/users?q=+john+", nil)
+
+	err := searchUsers(client)(w, r)
+	assert.Nil(err)
+
+	assert.Equal(1, client.searchUsers.count)
+	assert.Equal(getContext(r), client.searchUsers.lastCtx)
+	assert.Equal("john", client.searchUsers.lastTerm)
+
+	resp := w.Result()
+	assert.Equal("application/json", resp.Header.Get("Content-Type"))
+	assert.JSONEq(`[
+		{"id":47,"displayName":"John Smith","team":"Casework Team 1"},
+		{"id":48,"displayName":"Johnny Teamless"}
+	]`, w.Body.String())
+}
+
+func TestGetSearchUsersShortTerm(t *testing.T) {
+	assert := assert.New(t)
+
+	client := &mockSearchUsersClient{}
+	client.myDetails.data = sirius.MyDetails{Roles: []string{"Manager"}}
+
+	w := httptest.NewRecorder()
+	r, _ := http.NewRequest("GET", "/search/users?q=jo", nil)
+
+	err := searchUsers(client)(w, r)
+	assert.Nil(err)
+
+	assert.Equal(0, client.searchUsers.count)
+	assert.JSONEq(`[]`, w.Body.String())
+}
+
+func TestGetSearchUsersNotManager(t *testing.T) {
+	assert := assert.New(t)
+
+	client := &mockSearchUsersClient{}
+	client.myDetails.data = sirius.MyDetails{Roles: []string{"Case Worker"}}
+
+	w := httptest.NewRecorder()
+	r, _ := http.NewRequest("GET", "/search/users?q=john", nil)
+
+	err := searchUsers(client)(w, r)
+	assert.Equal(StatusError(http.StatusForbidden), err)
+	assert.Equal(0, client.searchUsers.count)
+}
+
+func TestGetSearchUsersError(t *testing.T) {
+	assert := assert.New(t)
+
+	client := &mockSearchUsersClient{}
+	client.myDetails.data = sirius.MyDetails{Roles: []string{"Manager"}}
+	client.searchUsers.err = errors.New("oops")
+
+	w := httptest.NewRecorder()
+	r, _ := http.NewRequest("GET", "/search/users?q=john", nil)
+
+	err := searchUsers(client)(w, r)
+	assert.Equal(client.searchUsers.err, err)
+}
+
+func TestBadMethodSearchUsers(t *testing.T) {
+	assert := assert.New(t)
+
+	w := httptest.NewRecorder()
+	r, _ := http.NewRequest("POST", "/search/users", nil)
+
+	err := searchUsers(nil)(w, r)
+	assert.Equal(StatusError(http.StatusMethodNotAllowed), err)
+}
@@ -7,9 +7,11 @@ import (
 	"html/template"
 	"io"
 	"log/slog"
+	"math"
 	"net/http"
 	"net/url"
 	"strconv"
+	"time"
 
 	"github.com/ministryofjustice/opg-go-common/securityheaders"
 	"github.com/ministryofjustice/opg-go-common/telemetry"
@@ -19,28 +21,44 @@ import (
 
 type Client interface {
 	AllCasesClient
+	CaseSummaryClient
 	TasksDashboardClient
 	CentralCasesClient
 	FeedbackClient
+	FilterPresetsClient
 	MarkWorkedClient
+	MarkUnworkedClient
 	PendingCasesClient
 	ReassignClient
+	ReassignTasksClient
+	SearchUsersClient
+	SearchCasesClient
 	RedirectClient
 	RequestNextCasesClient
 	RequestNextTaskClient
 	TasksClient
+	TaskStatusClient
 	TeamWorkInProgressClient
 	UserAllCasesClient
 	UserPendingCasesClient
 	UserTasksClient
+	ReadyClient
 }
 
 type Template interface {
 	ExecuteTemplate(io.Writer, string, interface{}) error
 }
 
-func New(logger *slog.Logger, client Client, templates map[string]*template.Template, prefix, siriusURL, siriusPublicURL, webDir string) http.Handler {
-	wrap := errorHandler(templates["error.gotmpl"], prefix, siriusPublicURL)
+func New(logger *slog.Logger, client Client, templates map[string]*template.Template, prefix, siriusURL, siriusPublicURL, webDir string, myDetailsTTL time.Duration, caseworkTeams TeamSelector, presetStore PresetStore, feedbackOutbox *FeedbackOutbox, undoKey []byte) http.Handler {
+	cache := newMyDetailsCache(client, myDetailsTTL)
+	client = cache
+
+	views := viewTemplates(templates)
+
+	handleError := errorHandler(views["error.gotmpl"], prefix, siriusPublicURL)
+	wrap := func(next Handler) http.Handler {
+		return negotiate(handleError(cache.handle(next)))
+	}
 
 	mux := http.NewServeMux()
 
@@ -48,43 +66,63 @@ func New(logger *slog.Logger, client Client, templates map[string]*template.Temp
 
 	mux.Handle("/pending-cases",
 		wrap(
-			pendingCases(client, templates["pending-cases.gotmpl"])))
+			pendingCases(client, views["pending-cases.gotmpl"], prefix, undoSigner(undoKey))))
 
 	mux.Handle("/tasks-dashboard",
 		wrap(
-			tasksDashboard(client, templates["tasks-dashboard.gotmpl"])))
+			tasksDashboard(client, views["tasks-dashboard.gotmpl"])))
 
 	mux.Handle("/tasks",
 		wrap(
-			tasks(client, templates["tasks.gotmpl"])))
+			tasks(client, views["tasks.gotmpl"], prefix)))
 
 	mux.Handle("/all-cases",
 		wrap(
-			allCases(client, templates["all-cases.gotmpl"])))
+			allCases(client, views["all-cases.gotmpl"], prefix)))
+
+	mux.Handle("/cases/",
+		wrap(
+			caseSummary(client, views["case-summary.gotmpl"])))
 
 	mux.Handle("/teams/central",
 		wrap(
-			centralCases(client, templates["central-cases.gotmpl"])))
+			centralCases(client, views["central-cases.gotmpl"], prefix)))
 
 	mux.Handle("/teams/work-in-progress/",
 		wrap(
-			teamWorkInProgress(client, templates["team-work-in-progress.gotmpl"])))
+			teamWorkInProgress(client, views["team-work-in-progress.gotmpl"], prefix, caseworkTeams, presetStore)))
+
+	mux.Handle("/filter-presets",
+		wrap(
+			filterPresets(client, presetStore)))
 
 	mux.Handle("/users/pending-cases/",
 		wrap(
-			userPendingCases(client, templates["user-pending-cases.gotmpl"])))
+			userPendingCases(client, views["user-pending-cases.gotmpl"], prefix)))
 
 	mux.Handle("/users/tasks/",
 		wrap(
-			userTasks(client, templates["user-tasks.gotmpl"])))
+			userTasks(client, views["user-tasks.gotmpl"], prefix)))
 
 	mux.Handle("/users/all-cases/",
 		wrap(
-			userAllCases(client, templates["user-all-cases.gotmpl"])))
+			userAllCases(client, views["user-all-cases.gotmpl"], prefix)))
 
 	mux.Handle("/reassign",
 		wrap(
-			reassign(client, templates["reassign.gotmpl"])))
+			reassign(client, views["reassign.gotmpl"])))
+
+	mux.Handle("/search",
+		wrap(
+			searchCases(client, views["search.gotmpl"])))
+
+	mux.Handle("/search/users",
+		wrap(
+			searchUsers(client)))
+
+	mux.Handle("/reassign-tasks",
+		wrap(
+			reassignTasks(client, views["reassign.gotmpl"])))
 
 	mux.Handle("/request-next-cases",
 		wrap(
@@ -94,16 +132,32 @@ func New(logger *slog.Logger, client Client, templates map[string]*template.Temp
 		wrap(
 			requestNextTask(client)))
 
+	mux.Handle("/start-task",
+		wrap(
+			startTask(client)))
+
+	mux.Handle("/complete-task",
+		wrap(
+			completeTask(client)))
+
 	mux.Handle("/mark-worked",
 		wrap(
-			markWorked(client)))
+			markWorked(client, views["mark-worked.gotmpl"], undoSigner(undoKey))))
+
+	mux.Handle("/mark-unworked",
+		wrap(
+			markUnworked(client, views["mark-worked.gotmpl"], undoSigner(undoKey))))
 
 	mux.Handle("/feedback",
 		wrap(
-			feedback(client, templates["feedback.gotmpl"])))
+			feedback(client, views["feedback.gotmpl"], feedbackOutbox)))
+
+	mux.Handle("/logout", logout(cache, siriusPublicURL))
 
 	mux.HandleFunc("/health-check", func(w http.ResponseWriter, r *http.Request) {})
 
+	mux.Handle("/ready", ready(client))
+
 	static := http.FileServer(http.Dir(webDir + "/static"))
 	mux.Handle("/assets/", static)
 	mux.Handle("/javascript/", static)
@@ -138,12 +192,40 @@ func (e StatusError) Code() int {
 
 type Handler func(w http.ResponseWriter, r *http.Request) error
 
+// fieldRuleMessages are shown in place of Sirius's own validation messages,
+// which are written for its developers rather than caseworkers.
+var fieldRuleMessages = map[string]string{
+	"isEmpty":              "is required",
+	"notBoolean":           "must be yes or no",
+	"notDigits":            "must be a number",
+	"notFound":             "could not be found",
+	"stringLengthTooLong":  "is too long",
+	"stringLengthTooShort": "is too short",
+	"dateInvalidDate":      "must be a real date",
+}
+
+func fieldRuleErrors(fieldErrors []sirius.FieldError) []sirius.FieldError {
+	var mapped []sirius.FieldError
+	for _, fieldError := range fieldErrors {
+		message, ok := fieldRuleMessages[fieldError.Rule]
+		if !ok {
+			message = "is not valid"
+		}
+
+		mapped = append(mapped, sirius.FieldError{Field: fieldError.Field, Message: message})
+	}
+
+	return mapped
+}
+
 type errorVars struct {
-	SiriusURL string
-	Path      string
+	SiriusURL string `json:"-"`
+	Path      string `json:"-"`
 
-	Code  int
-	Error string
+	Code        int                 `json:"code"`
+	Error       string              `json:"error"`
+	FieldErrors []sirius.FieldError `json:"fieldErrors"`
+	RetryAfter  int                 `json:"retryAfter"`
 }
 
 func errorHandler(tmplError Template, prefix, siriusURL string) func(next Handler) http.Handler {
@@ -158,8 +240,14 @@ func errorHandler(tmplError Template, prefix, siriusURL string) func(next Handle
 				}
 
 				if err == sirius.ErrUnauthorized {
-					http.Redirect(w, r, fmt.Sprintf("%s/auth?redirect=%s", siriusURL, url.QueryEscape(prefix+r.URL.Path)), http.StatusFound)
-					return
+					if !wantsJSON(w) {
+						http.Redirect(w, r, fmt.Sprintf("%s/auth?redirect=%s", siriusURL, url.QueryEscape(prefix+r.URL.Path)), http.StatusFound)
+						return
+					}
+
+					// Scripts cannot follow the redirect to sign in, so
+					// tell them their session has ended instead.
+					err = StatusError(http.StatusUnauthorized)
 				}
 
 				if redirect, ok := err.(RedirectError); ok {
@@ -167,28 +255,67 @@ func errorHandler(tmplError Template, prefix, siriusURL string) func(next Handle
 					return
 				}
 
+				logger := telemetry.LoggerFromContext(r.Context())
+
 				code := http.StatusInternalServerError
 				message := err.Error()
+				retryAfter := 0
+				var fieldErrors []sirius.FieldError
+
 				if status, ok := err.(StatusError); ok {
 					code = status.Code()
 				}
 
-				if statusError, ok := err.(*sirius.StatusError); ok {
+				var criteriaError sirius.CriteriaError
+				if errors.As(err, &criteriaError) {
+					code = http.StatusBadRequest
+					message = "The filters or sort order for this page are not valid"
+					fieldErrors = criteriaError
+				}
+
+				var unavailable sirius.UnavailableError
+				if errors.As(err, &unavailable) {
+					code = http.StatusServiceUnavailable
+					retryAfter = int(math.Ceil(unavailable.RetryAfter.Seconds()))
+					w.Header().Set("Retry-After", strconv.Itoa(retryAfter))
+				}
+
+				var statusError *sirius.StatusError
+				if errors.As(err, &statusError) {
 					code = statusError.Code
 					message = statusError.Title()
+					siriusFieldErrors := statusError.FieldErrors()
+
+					if statusError.Problem != nil {
+						fields := make([]string, len(siriusFieldErrors))
+						for i, fieldError := range siriusFieldErrors {
+							fields[i] = fieldError.Field
+						}
+
+						logger.Warn("sirius returned an error",
+							slog.Int("status", code),
+							slog.String("method", statusError.Method),
+							slog.String("title", message),
+							slog.Any("fields", fields))
+					}
+
+					if code == http.StatusBadRequest {
+						fieldErrors = fieldRuleErrors(siriusFieldErrors)
+					}
 				}
 
-				logger := telemetry.LoggerFromContext(r.Context())
 				if code == http.StatusInternalServerError {
 					logger.Error(err.Error())
 				}
 
 				w.WriteHeader(code)
 				err = tmplError.ExecuteTemplate(w, "page", errorVars{
-					SiriusURL: siriusURL,
-					Path:      "",
-					Code:      code,
-					Error:     message,
+					SiriusURL:   siriusURL,
+					Path:        "",
+					Code:        code,
+					Error:       message,
+					FieldErrors: fieldErrors,
+					RetryAfter:  retryAfter,
 				})
 
 				if err != nil {
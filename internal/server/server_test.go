@@ -12,6 +12,7 @@ import (
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/ministryofjustice/opg-go-common/telemetry"
 	"github.com/ministryofjustice/opg-sirius-lpa-dashboard/internal/sirius"
@@ -41,7 +42,7 @@ func contextWithLogger() (context.Context, *bytes.Buffer) {
 }
 
 func TestNew(t *testing.T) {
-	assert.Implements(t, (*http.Handler)(nil), New(nil, nil, nil, "", "", "", ""))
+	assert.Implements(t, (*http.Handler)(nil), New(nil, nil, nil, "", "", "", "", time.Minute, TeamSelector{}, nil, nil, []byte("key")))
 }
 
 func TestErrorHandler(t *testing.T) {
@@ -88,6 +89,29 @@ func TestErrorHandlerUnauthorized(t *testing.T) {
 	assert.Equal(0, tmplError.count)
 }
 
+func TestErrorHandlerUnauthorizedJSON(t *testing.T) {
+	assert := assert.New(t)
+
+	tmplError := &mockTemplate{}
+
+	wrap := errorHandler(tmplError, "/prefix", "http://sirius")
+	handler := wrap(func(w http.ResponseWriter, r *http.Request) error {
+		return sirius.ErrUnauthorized
+	})
+
+	w := httptest.NewRecorder()
+	r, _ := http.NewRequest("GET", "/path", nil)
+
+	handler.ServeHTTP(&jsonResponseWriter{ResponseWriter: w}, r)
+
+	resp := w.Result()
+	assert.Equal(http.StatusUnauthorized, resp.StatusCode)
+	assert.Equal("", resp.Header.Get("Location"))
+
+	assert.Equal(1, tmplError.count)
+	assert.Equal(http.StatusUnauthorized, tmplError.lastVars.(errorVars).Code)
+}
+
 func TestErrorHandlerRedirect(t *testing.T) {
 	assert := assert.New(t)
 
@@ -300,3 +324,158 @@ func TestCancelledContext(t *testing.T) {
 	assert.Equal(499, resp.StatusCode)
 	assert.Equal("", logBuf.String())
 }
+
+func TestErrorHandlerSiriusUnavailable(t *testing.T) {
+	assert := assert.New(t)
+
+	ctx, logBuf := contextWithLogger()
+
+	tmpl := &mockTemplate{}
+
+	wrap := errorHandler(tmpl, "/prefix", "http://sirius")
+	handler := wrap(func(w http.ResponseWriter, r *http.Request) error {
+		return fmt.Errorf("wrapped: %w", sirius.UnavailableError{RetryAfter: 1500 * time.Millisecond})
+	})
+
+	w := httptest.NewRecorder()
+	r, _ := http.NewRequestWithContext(ctx, "GET", "/path", nil)
+
+	handler.ServeHTTP(w, r)
+
+	resp := w.Result()
+	assert.Equal(http.StatusServiceUnavailable, resp.StatusCode)
+	assert.Equal("2", resp.Header.Get("Retry-After"))
+
+	assert.Equal(1, tmpl.count)
+	assert.Equal(errorVars{SiriusURL: "http://sirius", Code: http.StatusServiceUnavailable, Error: "wrapped: Sirius is unavailable", RetryAfter: 2}, tmpl.lastVars)
+	assert.Equal("", logBuf.String())
+}
+
+func TestErrorHandlerSiriusProblem(t *testing.T) {
+	assert := assert.New(t)
+
+	ctx, logBuf := contextWithLogger()
+
+	tmpl := &mockTemplate{}
+	statusError := &sirius.StatusError{
+		Code:   http.StatusBadRequest,
+		Method: http.MethodPut,
+		URL:    "http://sirius/lpa-api/v1/lpas/5",
+		Problem: &sirius.Problem{
+			Title:  "Bad Request",
+			Detail: "Payload failed validation",
+			ValidationErrors: map[string]map[string]string{
+				"worked": {"notBoolean": "Value must be a boolean"},
+			},
+		},
+	}
+
+	wrap := errorHandler(tmpl, "/prefix", "http://sirius")
+	handler := wrap(func(w http.ResponseWriter, r *http.Request) error {
+		return statusError
+	})
+
+	w := httptest.NewRecorder()
+	r, _ := http.NewRequestWithContext(ctx, "GET", "/path", nil)
+
+	handler.ServeHTTP(w, r)
+
+	resp := w.Result()
+	assert.Equal(http.StatusBadRequest, resp.StatusCode)
+
+	assert.Equal(1, tmpl.count)
+	assert.Equal(errorVars{
+		SiriusURL:   "http://sirius",
+		Code:        http.StatusBadRequest,
+		Error:       "Bad Request",
+		FieldErrors: []sirius.FieldError{{Field: "worked", Message: "must be yes or no"}},
+	}, tmpl.lastVars)
+
+	data := map[string]interface{}{}
+	err := json.Unmarshal(logBuf.Bytes(), &data)
+	assert.Nil(err)
+	assert.Equal("sirius returned an error", data["msg"])
+	assert.Equal("WARN", data["level"])
+	assert.Equal(float64(http.StatusBadRequest), data["status"])
+	assert.Equal("PUT", data["method"])
+	assert.Equal("Bad Request", data["title"])
+	assert.NotContains(data, "detail")
+	assert.NotContains(logBuf.String(), "Payload failed validation")
+	assert.Equal([]interface{}{"worked"}, data["fields"])
+}
+
+func TestErrorHandlerSiriusProblemWrapped(t *testing.T) {
+	assert := assert.New(t)
+
+	ctx, _ := contextWithLogger()
+
+	tmpl := &mockTemplate{}
+	statusError := &sirius.StatusError{
+		Code: http.StatusInternalServerError,
+		Problem: &sirius.Problem{
+			Title:  "Internal Server Error",
+			Detail: "Could not load donor Adrian Kurkjian",
+			ValidationErrors: map[string]map[string]string{
+				"donor": {"unknownRule": "Adrian Kurkjian is not valid"},
+			},
+		},
+	}
+
+	wrap := errorHandler(tmpl, "/prefix", "http://sirius")
+	handler := wrap(func(w http.ResponseWriter, r *http.Request) error {
+		return fmt.Errorf("loading case: %w", statusError)
+	})
+
+	w := httptest.NewRecorder()
+	r, _ := http.NewRequestWithContext(ctx, "GET", "/path", nil)
+
+	handler.ServeHTTP(w, r)
+
+	assert.Equal(http.StatusInternalServerError, w.Result().StatusCode)
+	assert.Equal(errorVars{
+		SiriusURL: "http://sirius",
+		Code:      http.StatusInternalServerError,
+		Error:     "Internal Server Error",
+	}, tmpl.lastVars)
+}
+
+func TestFieldRuleErrors(t *testing.T) {
+	assert.Equal(t, []sirius.FieldError{
+		{Field: "name", Message: "is required"},
+		{Field: "donor", Message: "is not valid"},
+	}, fieldRuleErrors([]sirius.FieldError{
+		{Field: "name", Message: "Value is required and can't be empty", Rule: "isEmpty"},
+		{Field: "donor", Message: "Adrian Kurkjian is not valid", Rule: "unknownRule"},
+	}))
+}
+
+func TestErrorHandlerCriteriaError(t *testing.T) {
+	assert := assert.New(t)
+
+	ctx, logBuf := contextWithLogger()
+
+	tmpl := &mockTemplate{}
+	criteriaError := sirius.CriteriaError{{Field: "status", Message: "must be one of pending"}}
+
+	wrap := errorHandler(tmpl, "/prefix", "http://sirius")
+	handler := wrap(func(w http.ResponseWriter, r *http.Request) error {
+		return criteriaError
+	})
+
+	w := httptest.NewRecorder()
+	r, _ := http.NewRequestWithContext(ctx, "GET", "/path", nil)
+
+	handler.ServeHTTP(w, r)
+
+	resp := w.Result()
+	assert.Equal(http.StatusBadRequest, resp.StatusCode)
+
+	assert.Equal(1, tmpl.count)
+	assert.Equal(errorVars{
+		SiriusURL:   "http://sirius",
+		Code:        http.StatusBadRequest,
+		Error:       "The filters or sort order for this page are not valid",
+		FieldErrors: []sirius.FieldError(criteriaError),
+	}, tmpl.lastVars)
+	assert.Equal("", logBuf.String())
+}
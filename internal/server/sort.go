@@ -0,0 +1,73 @@
+package server
+
+import (
+	"net/url"
+
+	"github.com/ministryofjustice/opg-sirius-lpa-dashboard/internal/sirius"
+)
+
+// caseSortFields are the Sirius fields a case table can be sorted by.
+var caseSortFields = []string{"donor.surname", "uId", "caseSubtype", "receiptDate", "status", "workedDate"}
+
+var caseSortSchema = sirius.CriteriaSchema{
+	Sorts: caseSortFields,
+}
+
+// Sort describes the order a table is shown in, so that its column headers
+// can link to the other orders.
+type Sort struct {
+	Field string `json:"field,omitempty"`
+	Order string `json:"order,omitempty"`
+
+	// Chosen is the sort parameter given in the request, if any, so that forms
+	// on the page can keep it.
+	Chosen string `json:"-"`
+
+	query string
+}
+
+type sortHeader struct {
+	Label    string
+	Href     string
+	AriaSort string
+}
+
+// newSort describes criteria, which may have had a default order added to
+// those chosen. Header links keep everything chosen except the sort and page.
+func newSort(schema sirius.CriteriaSchema, chosen, criteria sirius.Criteria) Sort {
+	query := schema.Encode(chosen)
+	sort := query.Get("sort")
+	query.Del("sort")
+
+	field, order := criteria.FirstSort()
+
+	return Sort{
+		Field:  field,
+		Order:  string(order),
+		Chosen: sort,
+		query:  query.Encode(),
+	}
+}
+
+// Header links to the table sorted by field, in the opposite order if it is
+// already sorted by it.
+func (s Sort) Header(field, label string) sortHeader {
+	header := sortHeader{
+		Label:    label,
+		AriaSort: "none",
+	}
+
+	order := sirius.Ascending
+	if s.Field == field {
+		if s.Order == string(sirius.Ascending) {
+			header.AriaSort = "ascending"
+			order = sirius.Descending
+		} else {
+			header.AriaSort = "descending"
+		}
+	}
+
+	header.Href = queryPrefix(s.query) + url.Values{"sort": {field + ":" + string(order)}}.Encode()
+
+	return header
+}
@@ -0,0 +1,57 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/ministryofjustice/opg-sirius-lpa-dashboard/internal/sirius"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewSort(t *testing.T) {
+	schema := sirius.CriteriaSchema{
+		Filters: []sirius.FilterField{{Name: "status"}},
+		Sorts:   []string{"receiptDate", "uId"},
+	}
+
+	chosen := sirius.Criteria{}.Filter("status", "pending").Sort("uId", sirius.Descending)
+	sort := newSort(schema, chosen, chosen.Page(3))
+
+	assert.Equal(t, Sort{Field: "uId", Order: "desc", Chosen: "uId:desc", query: "status=pending"}, sort)
+}
+
+func TestNewSortDefault(t *testing.T) {
+	chosen := sirius.Criteria{}
+	sort := newSort(caseSortSchema, chosen, chosen.Sort("receiptDate", sirius.Ascending))
+
+	assert.Equal(t, Sort{Field: "receiptDate", Order: "asc"}, sort)
+}
+
+func TestSortHeader(t *testing.T) {
+	testCases := map[string]struct {
+		sort     Sort
+		expected sortHeader
+	}{
+		"unsorted": {
+			sort:     Sort{},
+			expected: sortHeader{Label: "Received", Href: "?sort=receiptDate%3Aasc", AriaSort: "none"},
+		},
+		"other field": {
+			sort:     Sort{Field: "uId", Order: "asc", query: "status=pending"},
+			expected: sortHeader{Label: "Received", Href: "?status=pending&sort=receiptDate%3Aasc", AriaSort: "none"},
+		},
+		"ascending": {
+			sort:     Sort{Field: "receiptDate", Order: "asc"},
+			expected: sortHeader{Label: "Received", Href: "?sort=receiptDate%3Adesc", AriaSort: "ascending"},
+		},
+		"descending": {
+			sort:     Sort{Field: "receiptDate", Order: "desc"},
+			expected: sortHeader{Label: "Received", Href: "?sort=receiptDate%3Aasc", AriaSort: "descending"},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.sort.Header("receiptDate", "Received"))
+		})
+	}
+}
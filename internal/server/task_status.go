@@ -0,0 +1,45 @@
+package server
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/ministryofjustice/opg-sirius-lpa-dashboard/internal/sirius"
+)
+
+type TaskStatusClient interface {
+	StartTask(sirius.Context, int) error
+	CompleteTask(sirius.Context, int) error
+}
+
+func startTask(client TaskStatusClient) Handler {
+	return updateTask(client.StartTask)
+}
+
+func completeTask(client TaskStatusClient) Handler {
+	return updateTask(client.CompleteTask)
+}
+
+func updateTask(update func(sirius.Context, int) error) Handler {
+	return func(w http.ResponseWriter, r *http.Request) error {
+		if r.Method != http.MethodPost {
+			return StatusError(http.StatusMethodNotAllowed)
+		}
+
+		ctx := getContext(r)
+		if ctx.XSRFToken == "" {
+			return StatusError(http.StatusForbidden)
+		}
+
+		id, err := strconv.Atoi(r.PostFormValue("id"))
+		if err != nil {
+			return StatusError(http.StatusBadRequest)
+		}
+
+		if err := update(ctx, id); err != nil {
+			return err
+		}
+
+		return RedirectError("/tasks-dashboard")
+	}
+}
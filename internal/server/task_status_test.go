@@ -0,0 +1,131 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ministryofjustice/opg-sirius-lpa-dashboard/internal/sirius"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockTaskStatusClient struct {
+	startTask struct {
+		count   int
+		lastCtx sirius.Context
+		lastId  int
+		err     error
+	}
+	completeTask struct {
+		count   int
+		lastCtx sirius.Context
+		lastId  int
+		err     error
+	}
+}
+
+func (m *mockTaskStatusClient) StartTask(ctx sirius.Context, id int) error {
+	m.startTask.count += 1
+	m.startTask.lastCtx = ctx
+	m.startTask.lastId = id
+
+	return m.startTask.err
+}
+
+func (m *mockTaskStatusClient) CompleteTask(ctx sirius.Context, id int) error {
+	m.completeTask.count += 1
+	m.completeTask.lastCtx = ctx
+	m.completeTask.lastId = id
+
+	return m.completeTask.err
+}
+
+func TestPostStartTask(t *testing.T) {
+	assert := assert.New(t)
+
+	client := &mockTaskStatusClient{}
+
+	w := httptest.NewRecorder()
+	r, _ := http.NewRequest("POST", "/path", strings.NewReader("xsrfToken=abc&id=36"))
+	r.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+
+	err := startTask(client)(w, r)
+	assert.Equal(RedirectError("/tasks-dashboard"), err)
+
+	assert.Equal(1, client.startTask.count)
+	assert.Equal(getContext(r), client.startTask.lastCtx)
+	assert.Equal(36, client.startTask.lastId)
+	assert.Equal(0, client.completeTask.count)
+}
+
+func TestPostCompleteTask(t *testing.T) {
+	assert := assert.New(t)
+
+	client := &mockTaskStatusClient{}
+
+	w := httptest.NewRecorder()
+	r, _ := http.NewRequest("POST", "/path", strings.NewReader("xsrfToken=abc&id=36"))
+	r.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+
+	err := completeTask(client)(w, r)
+	assert.Equal(RedirectError("/tasks-dashboard"), err)
+
+	assert.Equal(1, client.completeTask.count)
+	assert.Equal(getContext(r), client.completeTask.lastCtx)
+	assert.Equal(36, client.completeTask.lastId)
+	assert.Equal(0, client.startTask.count)
+}
+
+func TestPostUpdateTaskError(t *testing.T) {
+	assert := assert.New(t)
+
+	client := &mockTaskStatusClient{}
+	client.completeTask.err = errors.New("oops")
+
+	w := httptest.NewRecorder()
+	r, _ := http.NewRequest("POST", "/path", strings.NewReader("xsrfToken=abc&id=36"))
+	r.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+
+	err := completeTask(client)(w, r)
+	assert.Equal(client.completeTask.err, err)
+}
+
+func TestPostUpdateTaskBadRequest(t *testing.T) {
+	testCases := map[string]struct {
+		Body   string
+		Status StatusError
+	}{
+		"no xsrf token": {Body: "id=36", Status: http.StatusForbidden},
+		"no id":         {Body: "xsrfToken=abc", Status: http.StatusBadRequest},
+		"bad id":        {Body: "xsrfToken=abc&id=what", Status: http.StatusBadRequest},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			client := &mockTaskStatusClient{}
+
+			w := httptest.NewRecorder()
+			r, _ := http.NewRequest("POST", "/path", strings.NewReader(tc.Body))
+			r.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+
+			err := startTask(client)(w, r)
+			assert.Equal(t, tc.Status, err)
+			assert.Equal(t, 0, client.startTask.count)
+		})
+	}
+}
+
+func TestBadMethodUpdateTask(t *testing.T) {
+	assert := assert.New(t)
+
+	client := &mockTaskStatusClient{}
+
+	w := httptest.NewRecorder()
+	r, _ := http.NewRequest("GET", "/path?id=36", nil)
+
+	err := startTask(client)(w, r)
+	assert.Equal(StatusError(http.StatusMethodNotAllowed), err)
+	assert.Equal(0, client.startTask.count)
+}
@@ -7,21 +7,21 @@ import (
 )
 
 type TasksClient interface {
-	CasesWithOpenTasksByAssignee(sirius.Context, int, int) ([]sirius.Case, *sirius.Pagination, error)
+	CasesWithOpenTasksByAssignee(sirius.Context, int, sirius.Criteria) ([]sirius.Case, *sirius.Pagination, error)
 	HasWorkableCase(sirius.Context, int) (bool, error)
 	MyDetails(sirius.Context) (sirius.MyDetails, error)
 }
 
 type tasksVars struct {
-	Cases           []sirius.Case
-	Pagination      *Pagination
-	HasWorkableCase bool
-	CanRequestCase  bool
-	IsManager       bool
-	XSRFToken       string
+	Cases           []sirius.Case `json:"cases"`
+	Pagination      *Pagination   `json:"pagination"`
+	HasWorkableCase bool          `json:"hasWorkableCase"`
+	CanRequestCase  bool          `json:"canRequestCase"`
+	IsManager       bool          `json:"isManager"`
+	XSRFToken       string        `json:"-"`
 }
 
-func tasks(client TasksClient, tmpl Template) Handler {
+func tasks(client TasksClient, tmpl Template, prefix string) Handler {
 	return func(w http.ResponseWriter, r *http.Request) error {
 		if r.Method != http.MethodGet {
 			return StatusError(http.StatusMethodNotAllowed)
@@ -34,7 +34,12 @@ func tasks(client TasksClient, tmpl Template) Handler {
 			return err
 		}
 
-		cases, pagination, err := client.CasesWithOpenTasksByAssignee(ctx, myDetails.ID, getPage(r))
+		pageSize, err := getPageSize(w, r, prefix)
+		if err != nil {
+			return err
+		}
+
+		cases, pagination, err := client.CasesWithOpenTasksByAssignee(ctx, myDetails.ID, withPageSize(sirius.Criteria{}.Page(getPage(r)), pageSize))
 		if err != nil {
 			return err
 		}
@@ -13,9 +13,9 @@ type TasksDashboardClient interface {
 }
 
 type tasksDashboardVars struct {
-	Tasks     []sirius.Task
-	Title     string
-	XSRFToken string
+	Tasks     []sirius.Task `json:"tasks"`
+	Title     string        `json:"title"`
+	XSRFToken string        `json:"-"`
 }
 
 func tasksDashboard(client TasksDashboardClient, tmpl Template) Handler {
@@ -32,7 +32,8 @@ func tasksDashboard(client TasksDashboardClient, tmpl Template) Handler {
 		}
 
 		criteria := sirius.Criteria{}.
-			Filter("status", "Not started").
+			Filter("status", sirius.TaskNotStarted).
+			Filter("status", sirius.TaskInProgress).
 			Sort("dueDate", sirius.Ascending).
 			Sort("name", sirius.Descending)
 
@@ -71,7 +71,7 @@ func TestGetTasksDashboard(t *testing.T) {
 	assert.Equal(1, client.tasksByAssignee.count)
 	assert.Equal(getContext(r), client.tasksByAssignee.lastCtx)
 	assert.Equal(14, client.tasksByAssignee.lastId)
-	assert.Equal(sirius.Criteria{}.Filter("status", "Not started").Sort("dueDate", sirius.Ascending).Sort("name", sirius.Descending), client.tasksByAssignee.lastCriteria)
+	assert.Equal(sirius.Criteria{}.Filter("status", "Not started").Filter("status", "In progress").Sort("dueDate", sirius.Ascending).Sort("name", sirius.Descending), client.tasksByAssignee.lastCriteria)
 
 	assert.Equal(1, template.count)
 	assert.Equal("page", template.lastName)
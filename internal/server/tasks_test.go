@@ -18,13 +18,13 @@ type mockTasksClient struct {
 		err     error
 	}
 	casesWithOpenTasksByAssignee struct {
-		count      int
-		lastCtx    sirius.Context
-		lastId     int
-		lastPage   int
-		data       []sirius.Case
-		pagination *sirius.Pagination
-		err        error
+		count        int
+		lastCtx      sirius.Context
+		lastId       int
+		lastCriteria sirius.Criteria
+		data         []sirius.Case
+		pagination   *sirius.Pagination
+		err          error
 	}
 	hasWorkableCase struct {
 		count   int
@@ -42,11 +42,11 @@ func (m *mockTasksClient) MyDetails(ctx sirius.Context) (sirius.MyDetails, error
 	return m.myDetails.data, m.myDetails.err
 }
 
-func (m *mockTasksClient) CasesWithOpenTasksByAssignee(ctx sirius.Context, id, page int) ([]sirius.Case, *sirius.Pagination, error) {
+func (m *mockTasksClient) CasesWithOpenTasksByAssignee(ctx sirius.Context, id int, criteria sirius.Criteria) ([]sirius.Case, *sirius.Pagination, error) {
 	m.casesWithOpenTasksByAssignee.count += 1
 	m.casesWithOpenTasksByAssignee.lastCtx = ctx
 	m.casesWithOpenTasksByAssignee.lastId = id
-	m.casesWithOpenTasksByAssignee.lastPage = page
+	m.casesWithOpenTasksByAssignee.lastCriteria = criteria
 
 	return m.casesWithOpenTasksByAssignee.data, m.casesWithOpenTasksByAssignee.pagination, m.casesWithOpenTasksByAssignee.err
 }
@@ -100,7 +100,7 @@ func TestGetTasks(t *testing.T) {
 			w := httptest.NewRecorder()
 			r, _ := http.NewRequest("GET", tc.URL, nil)
 
-			err := tasks(client, template)(w, r)
+			err := tasks(client, template, "")(w, r)
 			assert.Nil(err)
 
 			assert.Equal(1, client.myDetails.count)
@@ -109,7 +109,7 @@ func TestGetTasks(t *testing.T) {
 			assert.Equal(1, client.casesWithOpenTasksByAssignee.count)
 			assert.Equal(getContext(r), client.casesWithOpenTasksByAssignee.lastCtx)
 			assert.Equal(14, client.casesWithOpenTasksByAssignee.lastId)
-			assert.Equal(tc.Page, client.casesWithOpenTasksByAssignee.lastPage)
+			assert.Equal(sirius.Criteria{}.Page(tc.Page), client.casesWithOpenTasksByAssignee.lastCriteria)
 
 			assert.Equal(1, client.hasWorkableCase.count)
 			assert.Equal(getContext(r), client.hasWorkableCase.lastCtx)
@@ -127,6 +127,24 @@ func TestGetTasks(t *testing.T) {
 	}
 }
 
+func TestGetTasksPageSize(t *testing.T) {
+	assert := assert.New(t)
+
+	client := &mockTasksClient{}
+	client.myDetails.data = sirius.MyDetails{ID: 14}
+	client.casesWithOpenTasksByAssignee.pagination = &sirius.Pagination{}
+	template := &mockTemplate{}
+
+	w := httptest.NewRecorder()
+	r, _ := http.NewRequest("GET", "/path?page-size=100", nil)
+
+	err := tasks(client, template, "")(w, r)
+	assert.Nil(err)
+
+	assert.Equal(sirius.Criteria{}.Page(1).Limit(100), client.casesWithOpenTasksByAssignee.lastCriteria)
+	assert.Equal("100", w.Result().Cookies()[0].Value)
+}
+
 func TestGetTasksMyDetailsError(t *testing.T) {
 	assert := assert.New(t)
 
@@ -139,7 +157,7 @@ func TestGetTasksMyDetailsError(t *testing.T) {
 	w := httptest.NewRecorder()
 	r, _ := http.NewRequest("GET", "/path", nil)
 
-	err := tasks(client, template)(w, r)
+	err := tasks(client, template, "")(w, r)
 	assert.Equal(expectedError, err)
 
 	assert.Equal(1, client.myDetails.count)
@@ -163,7 +181,7 @@ func TestGetTasksQueryError(t *testing.T) {
 	w := httptest.NewRecorder()
 	r, _ := http.NewRequest("GET", "/path", nil)
 
-	err := tasks(client, template)(w, r)
+	err := tasks(client, template, "")(w, r)
 	assert.Equal(expectedError, err)
 
 	assert.Equal(1, client.myDetails.count)
@@ -183,7 +201,7 @@ func TestBadMethodTasks(t *testing.T) {
 	w := httptest.NewRecorder()
 	r, _ := http.NewRequest("DELETE", "/path", nil)
 
-	err := tasks(client, template)(w, r)
+	err := tasks(client, template, "")(w, r)
 
 	assert.Equal(StatusError(http.StatusMethodNotAllowed), err)
 
@@ -0,0 +1,97 @@
+package server
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/ministryofjustice/opg-sirius-lpa-dashboard/internal/sirius"
+)
+
+// DefaultCaseworkTeams matches the teams that have historically been shown as
+// casework teams.
+const DefaultCaseworkTeams = "name:^Casework Team;name:^Nottingham casework team"
+
+// TeamSelector chooses which teams are offered when changing the team shown on
+// the work in progress page. A team is chosen if it matches any of the IDs,
+// types or name patterns.
+type TeamSelector struct {
+	IDs      []int
+	Types    []string
+	Patterns []*regexp.Regexp
+}
+
+// ParseTeamSelector reads a selector from a list of rules separated by ";".
+// Each rule is one of "id:<team id>", "type:<team type handle or label>" or
+// "name:<regular expression>".
+func ParseTeamSelector(s string) (TeamSelector, error) {
+	var selector TeamSelector
+
+	for rule := range strings.SplitSeq(s, ";") {
+		rule = strings.TrimSpace(rule)
+		if rule == "" {
+			continue
+		}
+
+		kind, value, ok := strings.Cut(rule, ":")
+		if !ok || value == "" {
+			return TeamSelector{}, fmt.Errorf("team rule %q should be of the form kind:value", rule)
+		}
+
+		switch kind {
+		case "id":
+			id, err := strconv.Atoi(value)
+			if err != nil {
+				return TeamSelector{}, fmt.Errorf("team rule %q: %w", rule, err)
+			}
+			selector.IDs = append(selector.IDs, id)
+		case "type":
+			selector.Types = append(selector.Types, value)
+		case "name":
+			pattern, err := regexp.Compile(value)
+			if err != nil {
+				return TeamSelector{}, fmt.Errorf("team rule %q: %w", rule, err)
+			}
+			selector.Patterns = append(selector.Patterns, pattern)
+		default:
+			return TeamSelector{}, fmt.Errorf("team rule %q has unknown kind %q", rule, kind)
+		}
+	}
+
+	return selector, nil
+}
+
+func (s TeamSelector) Matches(team sirius.Team) bool {
+	for _, id := range s.IDs {
+		if team.ID == id {
+			return true
+		}
+	}
+
+	for _, t := range s.Types {
+		if team.Type.Handle != "" && (strings.EqualFold(t, team.Type.Handle) || strings.EqualFold(t, team.Type.Label)) {
+			return true
+		}
+	}
+
+	for _, pattern := range s.Patterns {
+		if pattern.MatchString(team.DisplayName) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Select returns the teams that match, keeping their order.
+func (s TeamSelector) Select(teams []sirius.Team) []sirius.Team {
+	var selected []sirius.Team
+	for _, team := range teams {
+		if s.Matches(team) {
+			selected = append(selected, team)
+		}
+	}
+
+	return selected
+}
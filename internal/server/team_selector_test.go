@@ -0,0 +1,59 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/ministryofjustice/opg-sirius-lpa-dashboard/internal/sirius"
+	"github.com/stretchr/testify/assert"
+)
+
+var testCaseworkTeams, _ = ParseTeamSelector(DefaultCaseworkTeams)
+
+func TestParseTeamSelector(t *testing.T) {
+	assert := assert.New(t)
+
+	selector, err := ParseTeamSelector("id:12; type:ALLOCATIONS;name:^Casework Team;;")
+	assert.Nil(err)
+	assert.Equal([]int{12}, selector.IDs)
+	assert.Equal([]string{"ALLOCATIONS"}, selector.Types)
+	assert.Len(selector.Patterns, 1)
+	assert.Equal("^Casework Team", selector.Patterns[0].String())
+}
+
+func TestParseTeamSelectorInvalid(t *testing.T) {
+	for name, s := range map[string]string{
+		"no kind":      "Casework Team",
+		"no value":     "name:",
+		"unknown kind": "colour:blue",
+		"bad id":       "id:twelve",
+		"bad pattern":  "name:(",
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := ParseTeamSelector(s)
+			assert.NotNil(t, err)
+		})
+	}
+}
+
+func TestTeamSelectorSelect(t *testing.T) {
+	selector, _ := ParseTeamSelector("id:4;type:allocations;name:^Casework Team")
+
+	teams := []sirius.Team{
+		{ID: 1, DisplayName: "Casework Team 1"},
+		{ID: 2, DisplayName: "Another team"},
+		{ID: 3, DisplayName: "Allocations", Type: sirius.TeamType{Handle: "ALLOCATIONS", Label: "Allocations"}},
+		{ID: 4, DisplayName: "Special team"},
+		{ID: 5, DisplayName: "Investigations", Type: sirius.TeamType{Handle: "INVESTIGATIONS", Label: "Investigations"}},
+	}
+
+	assert.Equal(t, []sirius.Team{teams[0], teams[2], teams[3]}, selector.Select(teams))
+}
+
+func TestDefaultCaseworkTeams(t *testing.T) {
+	selector, err := ParseTeamSelector(DefaultCaseworkTeams)
+	assert.Nil(t, err)
+
+	assert.True(t, selector.Matches(sirius.Team{DisplayName: "Casework Team 2"}))
+	assert.True(t, selector.Matches(sirius.Team{DisplayName: "Nottingham casework team 2"}))
+	assert.False(t, selector.Matches(sirius.Team{DisplayName: "my team"}))
+}
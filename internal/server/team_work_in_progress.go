@@ -1,6 +1,8 @@
 package server
 
 import (
+	"fmt"
+	"iter"
 	"net/http"
 	"net/url"
 	"strconv"
@@ -11,122 +13,92 @@ import (
 )
 
 type TeamWorkInProgressClient interface {
+	AllCasesByTeam(sirius.Context, int, sirius.Criteria) iter.Seq2[sirius.Case, error]
 	CasesByTeam(sirius.Context, int, sirius.Criteria) (*sirius.CasesByTeam, error)
 	MyDetails(sirius.Context) (sirius.MyDetails, error)
 	Teams(sirius.Context) ([]sirius.Team, error)
 }
 
 type teamWorkInProgressVars struct {
-	Cases          []sirius.Case
-	OldestCaseDate sirius.SiriusDate
-	Pagination     *Pagination
-	Today          time.Time
-	Stats          sirius.CasesByTeamMetadata
-	Team           sirius.Team
-	Teams          []sirius.Team
-	Filters        teamWorkInProgressFilters
-	IsCaseWorker   bool
+	Cases          []sirius.Case              `json:"cases"`
+	OldestCaseDate sirius.SiriusDate          `json:"oldestCaseDate,omitzero"`
+	Pagination     *Pagination                `json:"pagination"`
+	Today          time.Time                  `json:"today,omitzero"`
+	Stats          sirius.CasesByTeamMetadata `json:"stats"`
+	Team           sirius.Team                `json:"team"`
+	Teams          []sirius.Team              `json:"teams"`
+	Filters        teamWorkInProgressFilters  `json:"filters"`
+	IsCaseWorker   bool                       `json:"isCaseWorker"`
+	ExportQuery    string                     `json:"-"`
+	Sort           Sort                       `json:"sort"`
+	Presets        []presetLink               `json:"presets"`
+	PresetQuery    string                     `json:"-"`
+	XSRFToken      string                     `json:"-"`
+}
+
+var teamWorkInProgressSchema = sirius.CriteriaSchema{
+	Filters: []sirius.FilterField{
+		{Name: "allocation", Type: sirius.IntFilter, Multiple: true},
+		{Name: "status", Multiple: true, Values: []string{"pending", "pending-worked"}},
+		{Name: "date-from", Type: sirius.DateFilter},
+		{Name: "date-to", Type: sirius.DateFilter},
+		{Name: "lpa-type", Values: []string{"pfa", "hw", "both"}},
+	},
+	Sorts: caseSortFields,
 }
 
 type teamWorkInProgressFilters struct {
-	Set        bool
-	Allocation []int
-	Status     []string
-	DateFrom   time.Time
-	DateTo     time.Time
-	LpaType    string
+	Set        bool      `json:"set"`
+	Allocation []int     `json:"allocation"`
+	Status     []string  `json:"status"`
+	DateFrom   time.Time `json:"dateFrom,omitzero"`
+	DateTo     time.Time `json:"dateTo,omitzero"`
+	LpaType    string    `json:"lpaType"`
+
+	criteria sirius.Criteria
 }
 
 func (f teamWorkInProgressFilters) Encode() string {
-	if !f.Set {
-		return ""
-	}
-
-	form := url.Values{}
-	for _, v := range f.Allocation {
-		form.Add("allocation", strconv.Itoa(v))
-	}
-	for _, v := range f.Status {
-		form.Add("status", v)
-	}
-	if !f.DateFrom.IsZero() {
-		form.Add("date-from", f.DateFrom.Format("2006-01-02"))
-	}
-	if !f.DateTo.IsZero() {
-		form.Add("date-to", f.DateTo.Format("2006-01-02"))
-	}
-	if f.LpaType != "" {
-		form.Add("lpa-type", f.LpaType)
-	}
-
-	return form.Encode()
+	return teamWorkInProgressSchema.Encode(f.criteria).Encode()
 }
 
 func (f teamWorkInProgressFilters) Criteria() sirius.Criteria {
-	c := sirius.Criteria{}
-	if !f.Set {
-		return c
-	}
-
-	for _, v := range f.Allocation {
-		c = c.Filter("allocation", strconv.Itoa(v))
-	}
-	for _, v := range f.Status {
-		c = c.Filter("status", v)
-	}
-	if !f.DateFrom.IsZero() {
-		c = c.Filter("date-from", f.DateFrom.Format("2006-01-02"))
-	}
-	if !f.DateTo.IsZero() {
-		c = c.Filter("date-to", f.DateTo.Format("2006-01-02"))
-	}
-	if f.LpaType != "" {
-		c = c.Filter("lpa-type", f.LpaType)
-	}
-
-	return c
+	return f.criteria
 }
 
-func newTeamWorkInProgressFilters(form url.Values) teamWorkInProgressFilters {
-	filters := teamWorkInProgressFilters{}
+func newTeamWorkInProgressFilters(form url.Values) (teamWorkInProgressFilters, error) {
+	criteria, err := teamWorkInProgressSchema.Parse(form)
+	if err != nil {
+		return teamWorkInProgressFilters{}, err
+	}
 
-	if allocation, ok := form["allocation"]; ok {
-		for _, v := range allocation {
-			if i, err := strconv.Atoi(v); err == nil {
-				filters.Allocation = append(filters.Allocation, i)
-				filters.Set = true
-			}
-		}
+	filters := teamWorkInProgressFilters{
+		Set:      criteria.IsFiltered(),
+		Status:   criteria.FilterValues("status"),
+		criteria: criteria,
 	}
 
-	if status, ok := form["status"]; ok {
-		for _, v := range status {
-			if v == "pending" || v == "pending-worked" {
-				filters.Status = append(filters.Status, v)
-				filters.Set = true
-			}
-		}
+	for _, v := range criteria.FilterValues("allocation") {
+		i, _ := strconv.Atoi(v)
+		filters.Allocation = append(filters.Allocation, i)
 	}
 
-	if v, err := time.Parse("2006-01-02", form.Get("date-from")); err == nil {
-		filters.DateFrom = v
-		filters.Set = true
+	for _, v := range criteria.FilterValues("date-from") {
+		filters.DateFrom, _ = time.Parse("2006-01-02", v)
 	}
 
-	if v, err := time.Parse("2006-01-02", form.Get("date-to")); err == nil {
-		filters.DateTo = v
-		filters.Set = true
+	for _, v := range criteria.FilterValues("date-to") {
+		filters.DateTo, _ = time.Parse("2006-01-02", v)
 	}
 
-	if v := form.Get("lpa-type"); v == "pfa" || v == "hw" || v == "both" {
+	for _, v := range criteria.FilterValues("lpa-type") {
 		filters.LpaType = v
-		filters.Set = true
 	}
 
-	return filters
+	return filters, nil
 }
 
-func teamWorkInProgress(client TeamWorkInProgressClient, tmpl Template) Handler {
+func teamWorkInProgress(client TeamWorkInProgressClient, tmpl Template, prefix string, caseworkTeams TeamSelector, store PresetStore) Handler {
 	return func(w http.ResponseWriter, r *http.Request) error {
 		if r.Method != http.MethodGet {
 			return StatusError(http.StatusMethodNotAllowed)
@@ -153,22 +125,48 @@ func teamWorkInProgress(client TeamWorkInProgressClient, tmpl Template) Handler
 			return err
 		}
 
-		var caseworkTeams []sirius.Team
-		for _, team := range teams {
-			if strings.HasPrefix(team.DisplayName, "Casework Team") || strings.HasPrefix(team.DisplayName, "Nottingham casework team") {
-				caseworkTeams = append(caseworkTeams, team)
-			}
-		}
-
+		// Teams with a type, such as Allocations, do not hold casework so are
+		// only shown when they have been chosen as casework teams.
 		currentTeam, ok := findTeam(id, teams)
-		if !ok {
+		if !ok || (currentTeam.Type.Handle != "" && !caseworkTeams.Matches(currentTeam)) {
 			return StatusError(http.StatusNotFound)
 		}
 
+		presets, err := store.List(ctx.Context, myDetails.ID)
+		if err != nil {
+			return err
+		}
+
+		if r.URL.RawQuery == "" && isMemberOf(myDetails, id) {
+			for _, preset := range presets {
+				if preset.Default && preset.Query != "" {
+					return RedirectError(fmt.Sprintf("/teams/work-in-progress/%d?%s", id, preset.Query))
+				}
+			}
+		}
+
 		page := getPage(r)
-		filters := newTeamWorkInProgressFilters(r.Form)
+		filters, err := newTeamWorkInProgressFilters(r.Form)
+		if err != nil {
+			return err
+		}
 
-		result, err := client.CasesByTeam(ctx, id, filters.Criteria().Page(page))
+		format, err := getExportFormat(r)
+		if err != nil {
+			return err
+		}
+
+		pageSize, err := getPageSize(w, r, prefix)
+		if err != nil {
+			return err
+		}
+
+		if format != "" {
+			cases := client.AllCasesByTeam(ctx, id, filters.Criteria())
+			return exportCases(w, format, fmt.Sprintf("team-%d-work-in-progress", id), cases, time.Now())
+		}
+
+		result, err := client.CasesByTeam(ctx, id, withPageSize(filters.Criteria().Page(page), pageSize))
 		if err != nil {
 			return err
 		}
@@ -179,15 +177,30 @@ func teamWorkInProgress(client TeamWorkInProgressClient, tmpl Template) Handler
 			Pagination:   newPaginationWithQuery(result.Pagination, filters.Encode()),
 			Today:        time.Now(),
 			Team:         currentTeam,
-			Teams:        caseworkTeams,
+			Teams:        caseworkTeams.Select(teams),
 			Filters:      filters,
 			IsCaseWorker: myDetails.HasRole("Self Allocation User"),
+			ExportQuery:  queryPrefix(filters.Encode()),
+			Sort:         newSort(teamWorkInProgressSchema, filters.Criteria(), filters.Criteria()),
+			Presets:      newPresetLinks(presets, filters.Encode()),
+			PresetQuery:  filters.Encode(),
+			XSRFToken:    ctx.XSRFToken,
 		}
 
 		return tmpl.ExecuteTemplate(w, "page", vars)
 	}
 }
 
+func isMemberOf(myDetails sirius.MyDetails, teamID int) bool {
+	for _, team := range myDetails.Teams {
+		if team.ID == teamID {
+			return true
+		}
+	}
+
+	return false
+}
+
 func findTeam(id int, teams []sirius.Team) (sirius.Team, bool) {
 	for _, team := range teams {
 		if id == team.ID {
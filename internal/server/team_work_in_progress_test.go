@@ -2,17 +2,27 @@ package server
 
 import (
 	"errors"
+	"iter"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
 	"testing"
 	"time"
 
+	"github.com/ministryofjustice/opg-sirius-lpa-dashboard/internal/presets"
 	"github.com/ministryofjustice/opg-sirius-lpa-dashboard/internal/sirius"
 	"github.com/stretchr/testify/assert"
 )
 
 type mockTeamWorkInProgressClient struct {
+	allCasesByTeam struct {
+		count        int
+		lastCtx      sirius.Context
+		lastId       int
+		lastCriteria sirius.Criteria
+		data         []sirius.Case
+		err          error
+	}
 	casesByTeam struct {
 		count        int
 		lastCtx      sirius.Context
@@ -35,6 +45,15 @@ type mockTeamWorkInProgressClient struct {
 	}
 }
 
+func (m *mockTeamWorkInProgressClient) AllCasesByTeam(ctx sirius.Context, id int, criteria sirius.Criteria) iter.Seq2[sirius.Case, error] {
+	m.allCasesByTeam.count += 1
+	m.allCasesByTeam.lastCtx = ctx
+	m.allCasesByTeam.lastId = id
+	m.allCasesByTeam.lastCriteria = criteria
+
+	return mockCaseSeq(m.allCasesByTeam.data, m.allCasesByTeam.err)
+}
+
 func (m *mockTeamWorkInProgressClient) CasesByTeam(ctx sirius.Context, id int, criteria sirius.Criteria) (*sirius.CasesByTeam, error) {
 	m.casesByTeam.count += 1
 	m.casesByTeam.lastCtx = ctx
@@ -99,7 +118,7 @@ func TestGetTeamWorkInProgress(t *testing.T) {
 	w := httptest.NewRecorder()
 	r, _ := http.NewRequest("GET", "/teams/work-in-progress/1", nil)
 
-	err := teamWorkInProgress(client, template)(w, r)
+	err := teamWorkInProgress(client, template, "", testCaseworkTeams, &mockPresetStore{})(w, r)
 	assert.Nil(err)
 
 	assert.Equal(1, client.myDetails.count)
@@ -121,11 +140,12 @@ func TestGetTeamWorkInProgress(t *testing.T) {
 	vars.Today = time.Time{}
 
 	assert.Equal(teamWorkInProgressVars{
-		Cases:      client.casesByTeam.data.Cases,
-		Team:       client.teams.data[0],
-		Pagination: newPaginationWithQuery(client.casesByTeam.data.Pagination, ""),
-		Stats:      client.casesByTeam.data.Stats,
-		Teams:      []sirius.Team{client.teams.data[1], client.teams.data[2]},
+		Cases:       client.casesByTeam.data.Cases,
+		Team:        client.teams.data[0],
+		Pagination:  newPaginationWithQuery(client.casesByTeam.data.Pagination, ""),
+		Stats:       client.casesByTeam.data.Stats,
+		Teams:       []sirius.Team{client.teams.data[1], client.teams.data[2]},
+		ExportQuery: "?",
 	}, vars)
 }
 
@@ -142,7 +162,7 @@ func TestGetTeamWorkInProgressBadPath(t *testing.T) {
 			w := httptest.NewRecorder()
 			r, _ := http.NewRequest("GET", url, nil)
 
-			err := teamWorkInProgress(nil, nil)(w, r)
+			err := teamWorkInProgress(nil, nil, "", testCaseworkTeams, nil)(w, r)
 			assert.Equal(StatusError(http.StatusNotFound), err)
 		})
 	}
@@ -173,7 +193,7 @@ func TestGetTeamWorkInProgressPage(t *testing.T) {
 	w := httptest.NewRecorder()
 	r, _ := http.NewRequest("GET", "/teams/work-in-progress/1?page=4", nil)
 
-	err := teamWorkInProgress(client, template)(w, r)
+	err := teamWorkInProgress(client, template, "", testCaseworkTeams, &mockPresetStore{})(w, r)
 	assert.Nil(err)
 
 	assert.Equal(1, client.myDetails.count)
@@ -195,12 +215,37 @@ func TestGetTeamWorkInProgressPage(t *testing.T) {
 	vars.Today = time.Time{}
 
 	assert.Equal(teamWorkInProgressVars{
-		Cases: client.casesByTeam.data.Cases,
-		Team:  client.teams.data[0],
-		Teams: client.teams.data,
+		Cases:       client.casesByTeam.data.Cases,
+		Team:        client.teams.data[0],
+		Teams:       client.teams.data,
+		ExportQuery: "?",
 	}, vars)
 }
 
+func TestGetTeamWorkInProgressPageSize(t *testing.T) {
+	assert := assert.New(t)
+
+	client := &mockTeamWorkInProgressClient{}
+	client.myDetails.data = sirius.MyDetails{
+		Roles: []string{"Manager"},
+	}
+	client.casesByTeam.data = &sirius.CasesByTeam{}
+	client.teams.data = []sirius.Team{{
+		ID:          1,
+		DisplayName: "Casework Team 1",
+	}}
+	template := &mockTemplate{}
+
+	w := httptest.NewRecorder()
+	r, _ := http.NewRequest("GET", "/teams/work-in-progress/1?allocation=123&page-size=25", nil)
+
+	err := teamWorkInProgress(client, template, "", testCaseworkTeams, &mockPresetStore{})(w, r)
+	assert.Nil(err)
+
+	assert.Equal(sirius.Criteria{}.Filter("allocation", "123").Page(1).Limit(25), client.casesByTeam.lastCriteria)
+	assert.Equal("25", w.Result().Cookies()[0].Value)
+}
+
 func TestGetTeamWorkInProgressFiltered(t *testing.T) {
 	assert := assert.New(t)
 
@@ -226,7 +271,7 @@ func TestGetTeamWorkInProgressFiltered(t *testing.T) {
 	w := httptest.NewRecorder()
 	r, _ := http.NewRequest("GET", "/teams/work-in-progress/1?allocation=123", nil)
 
-	err := teamWorkInProgress(client, template)(w, r)
+	err := teamWorkInProgress(client, template, "", testCaseworkTeams, &mockPresetStore{})(w, r)
 	assert.Nil(err)
 
 	assert.Equal(1, client.myDetails.count)
@@ -254,7 +299,11 @@ func TestGetTeamWorkInProgressFiltered(t *testing.T) {
 		Filters: teamWorkInProgressFilters{
 			Set:        true,
 			Allocation: []int{123},
+			criteria:   sirius.Criteria{}.Filter("allocation", "123"),
 		},
+		ExportQuery: "?allocation=123&",
+		Sort:        Sort{query: "allocation=123"},
+		PresetQuery: "allocation=123",
 	}, vars)
 }
 
@@ -271,7 +320,7 @@ func TestGetTeamWorkInProgressForbidden(t *testing.T) {
 	w := httptest.NewRecorder()
 	r, _ := http.NewRequest("GET", "/teams/work-in-progress/1", nil)
 
-	err := teamWorkInProgress(client, template)(w, r)
+	err := teamWorkInProgress(client, template, "", testCaseworkTeams, &mockPresetStore{})(w, r)
 	assert.Equal(StatusError(http.StatusForbidden), err)
 
 	assert.Equal(1, client.myDetails.count)
@@ -292,7 +341,7 @@ func TestGetTeamWorkInProgressTeamDoesNotExist(t *testing.T) {
 	w := httptest.NewRecorder()
 	r, _ := http.NewRequest("GET", "/teams/work-in-progress/12", nil)
 
-	err := teamWorkInProgress(client, template)(w, r)
+	err := teamWorkInProgress(client, template, "", testCaseworkTeams, &mockPresetStore{})(w, r)
 	assert.Equal(StatusError(http.StatusNotFound), err)
 
 	assert.Equal(1, client.myDetails.count)
@@ -304,6 +353,36 @@ func TestGetTeamWorkInProgressTeamDoesNotExist(t *testing.T) {
 	assert.Equal(0, client.casesByTeam.count)
 }
 
+func TestGetTeamWorkInProgressTypedTeam(t *testing.T) {
+	assert := assert.New(t)
+
+	client := &mockTeamWorkInProgressClient{}
+	client.myDetails.data = sirius.MyDetails{
+		Roles: []string{"Manager"},
+	}
+	client.teams.data = []sirius.Team{{
+		ID:          12,
+		DisplayName: "Allocations",
+		Type:        sirius.TeamType{Handle: "ALLOCATIONS", Label: "Allocations"},
+	}}
+	client.casesByTeam.data = &sirius.CasesByTeam{
+		Pagination: &sirius.Pagination{},
+	}
+	template := &mockTemplate{}
+
+	w := httptest.NewRecorder()
+	r, _ := http.NewRequest("GET", "/teams/work-in-progress/12", nil)
+
+	err := teamWorkInProgress(client, template, "", testCaseworkTeams, &mockPresetStore{})(w, r)
+	assert.Equal(StatusError(http.StatusNotFound), err)
+	assert.Equal(0, client.casesByTeam.count)
+
+	caseworkTeams, _ := ParseTeamSelector("type:allocations")
+	err = teamWorkInProgress(client, template, "", caseworkTeams, &mockPresetStore{})(w, r)
+	assert.Nil(err)
+	assert.Equal(1, client.casesByTeam.count)
+}
+
 func TestGetTeamWorkInProgressMyDetailsError(t *testing.T) {
 	assert := assert.New(t)
 
@@ -316,7 +395,7 @@ func TestGetTeamWorkInProgressMyDetailsError(t *testing.T) {
 	w := httptest.NewRecorder()
 	r, _ := http.NewRequest("GET", "/teams/work-in-progress/1", nil)
 
-	err := teamWorkInProgress(client, template)(w, r)
+	err := teamWorkInProgress(client, template, "", testCaseworkTeams, &mockPresetStore{})(w, r)
 	assert.Equal(expectedError, err)
 
 	assert.Equal(1, client.myDetails.count)
@@ -341,7 +420,7 @@ func TestGetTeamWorkInProgressTeamsError(t *testing.T) {
 	w := httptest.NewRecorder()
 	r, _ := http.NewRequest("GET", "/teams/work-in-progress/1", nil)
 
-	err := teamWorkInProgress(client, template)(w, r)
+	err := teamWorkInProgress(client, template, "", testCaseworkTeams, &mockPresetStore{})(w, r)
 	assert.Equal(expectedError, err)
 
 	assert.Equal(1, client.myDetails.count)
@@ -373,7 +452,7 @@ func TestGetTeamWorkInProgressQueryError(t *testing.T) {
 	w := httptest.NewRecorder()
 	r, _ := http.NewRequest("GET", "/teams/work-in-progress/1", nil)
 
-	err := teamWorkInProgress(client, template)(w, r)
+	err := teamWorkInProgress(client, template, "", testCaseworkTeams, &mockPresetStore{})(w, r)
 	assert.Equal(expectedError, err)
 
 	assert.Equal(1, client.myDetails.count)
@@ -397,7 +476,7 @@ func TestBadMethodTeamWorkInProgress(t *testing.T) {
 	w := httptest.NewRecorder()
 	r, _ := http.NewRequest("DELETE", "/path", nil)
 
-	err := teamWorkInProgress(client, template)(w, r)
+	err := teamWorkInProgress(client, template, "", testCaseworkTeams, &mockPresetStore{})(w, r)
 
 	assert.Equal(StatusError(http.StatusMethodNotAllowed), err)
 
@@ -428,8 +507,8 @@ func TestTeamWorkInProgressFilters(t *testing.T) {
 				Filter("date-to", "2021-01-03").
 				Filter("lpa-type", "both"),
 		},
-		"date-range-bad": {
-			Input:    "date-from=what&date-to=huh",
+		"date-range-empty": {
+			Input:    "date-from=&date-to=",
 			Encoded:  "",
 			Criteria: sirius.Criteria{},
 		},
@@ -443,25 +522,228 @@ func TestTeamWorkInProgressFilters(t *testing.T) {
 			Encoded:  "lpa-type=pfa",
 			Criteria: sirius.Criteria{}.Filter("lpa-type", "pfa"),
 		},
-		"lpa-type-unknown": {
-			Input:    "lpa-type=what",
-			Encoded:  "",
-			Criteria: sirius.Criteria{},
-		},
-		"status-bad": {
-			Input:    "status=what",
-			Encoded:  "",
-			Criteria: sirius.Criteria{},
-		},
 	}
 
 	for name, tc := range testCases {
 		t.Run(name, func(t *testing.T) {
 			query, _ := url.ParseQuery(tc.Input)
-			filters := newTeamWorkInProgressFilters(query)
+			filters, err := newTeamWorkInProgressFilters(query)
 
+			assert.Nil(t, err)
 			assert.Equal(t, tc.Encoded, filters.Encode())
 			assert.Equal(t, tc.Criteria, filters.Criteria())
 		})
 	}
 }
+
+func TestTeamWorkInProgressFiltersInvalid(t *testing.T) {
+	testCases := map[string]struct {
+		Input string
+		Field string
+	}{
+		"allocation-bad":   {Input: "allocation=what", Field: "allocation"},
+		"date-range-bad":   {Input: "date-from=what", Field: "date-from"},
+		"lpa-type-unknown": {Input: "lpa-type=what", Field: "lpa-type"},
+		"status-bad":       {Input: "status=what", Field: "status"},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			query, _ := url.ParseQuery(tc.Input)
+			_, err := newTeamWorkInProgressFilters(query)
+
+			criteriaError, ok := err.(sirius.CriteriaError)
+			assert.True(t, ok)
+			assert.Equal(t, tc.Field, criteriaError[0].Field)
+		})
+	}
+}
+
+func TestGetTeamWorkInProgressBadFilter(t *testing.T) {
+	assert := assert.New(t)
+
+	client := &mockTeamWorkInProgressClient{}
+	client.myDetails.data = sirius.MyDetails{
+		Roles: []string{"Manager"},
+	}
+	client.teams.data = []sirius.Team{{
+		ID:          1,
+		DisplayName: "Casework Team 1",
+	}}
+
+	w := httptest.NewRecorder()
+	r, _ := http.NewRequest("GET", "/teams/work-in-progress/1?status=what", nil)
+
+	err := teamWorkInProgress(client, nil, "", testCaseworkTeams, &mockPresetStore{})(w, r)
+	assert.IsType(sirius.CriteriaError{}, err)
+
+	assert.Equal(0, client.casesByTeam.count)
+}
+
+func TestGetTeamWorkInProgressExport(t *testing.T) {
+	assert := assert.New(t)
+
+	client := &mockTeamWorkInProgressClient{}
+	client.myDetails.data = sirius.MyDetails{
+		Roles: []string{"Manager"},
+	}
+	client.teams.data = []sirius.Team{{
+		ID:          1,
+		DisplayName: "Casework Team 1",
+	}}
+	client.allCasesByTeam.data = []sirius.Case{{
+		Uid:   "7000-0000-0001",
+		Donor: sirius.Donor{Firstname: "Adrian", Surname: "Kurkjian"},
+	}}
+	template := &mockTemplate{}
+
+	w := httptest.NewRecorder()
+	r, _ := http.NewRequest("GET", "/teams/work-in-progress/1?allocation=123&page=3&format=csv", nil)
+	r.AddCookie(&http.Cookie{Name: pageSizeCookie, Value: "50"})
+
+	err := teamWorkInProgress(client, template, "", testCaseworkTeams, &mockPresetStore{})(w, r)
+	assert.Nil(err)
+
+	assert.Equal(0, client.casesByTeam.count)
+	assert.Equal(1, client.allCasesByTeam.count)
+	assert.Equal(getContext(r), client.allCasesByTeam.lastCtx)
+	assert.Equal(1, client.allCasesByTeam.lastId)
+	assert.Equal(sirius.Criteria{}.Filter("allocation", "123"), client.allCasesByTeam.lastCriteria)
+
+	assert.Equal(0, template.count)
+
+	resp := w.Result()
+	assert.Equal("text/csv; charset=utf-8", resp.Header.Get("Content-Type"))
+	assert.Equal(`attachment; filename="team-1-work-in-progress-`+time.Now().Format("2006-01-02")+`.csv"`, resp.Header.Get("Content-Disposition"))
+	assert.Contains(w.Body.String(), "Adrian Kurkjian,7000-0000-0001,")
+}
+
+func TestGetTeamWorkInProgressExportForbidden(t *testing.T) {
+	assert := assert.New(t)
+
+	client := &mockTeamWorkInProgressClient{}
+	client.myDetails.data = sirius.MyDetails{
+		Roles: []string{"Case Manager"},
+	}
+
+	w := httptest.NewRecorder()
+	r, _ := http.NewRequest("GET", "/teams/work-in-progress/1?format=csv", nil)
+
+	err := teamWorkInProgress(client, nil, "", testCaseworkTeams, &mockPresetStore{})(w, r)
+	assert.Equal(StatusError(http.StatusForbidden), err)
+
+	assert.Equal(0, client.allCasesByTeam.count)
+}
+
+func TestGetTeamWorkInProgressExportBadFormat(t *testing.T) {
+	assert := assert.New(t)
+
+	client := &mockTeamWorkInProgressClient{}
+	client.myDetails.data = sirius.MyDetails{
+		Roles: []string{"Manager"},
+	}
+	client.teams.data = []sirius.Team{{
+		ID:          1,
+		DisplayName: "Casework Team 1",
+	}}
+
+	w := httptest.NewRecorder()
+	r, _ := http.NewRequest("GET", "/teams/work-in-progress/1?format=pdf", nil)
+
+	err := teamWorkInProgress(client, nil, "", testCaseworkTeams, &mockPresetStore{})(w, r)
+	assert.Equal(StatusError(http.StatusBadRequest), err)
+
+	assert.Equal(0, client.allCasesByTeam.count)
+	assert.Equal(0, client.casesByTeam.count)
+}
+
+func TestGetTeamWorkInProgressExportError(t *testing.T) {
+	assert := assert.New(t)
+
+	expectedError := errors.New("oops")
+
+	client := &mockTeamWorkInProgressClient{}
+	client.myDetails.data = sirius.MyDetails{
+		Roles: []string{"Manager"},
+	}
+	client.teams.data = []sirius.Team{{
+		ID:          1,
+		DisplayName: "Casework Team 1",
+	}}
+	client.allCasesByTeam.err = expectedError
+
+	w := httptest.NewRecorder()
+	r, _ := http.NewRequest("GET", "/teams/work-in-progress/1?format=xlsx", nil)
+
+	err := teamWorkInProgress(client, nil, "", testCaseworkTeams, &mockPresetStore{})(w, r)
+	assert.Equal(expectedError, err)
+
+	assert.Equal("", w.Result().Header.Get("Content-Disposition"))
+}
+
+func TestGetTeamWorkInProgressDefaultPreset(t *testing.T) {
+	assert := assert.New(t)
+
+	client := &mockTeamWorkInProgressClient{}
+	client.myDetails.data = sirius.MyDetails{
+		ID:    14,
+		Roles: []string{"Manager"},
+		Teams: []sirius.MyDetailsTeam{{ID: 1}},
+	}
+	client.teams.data = []sirius.Team{{ID: 1}, {ID: 2}}
+	store := &mockPresetStore{}
+	store.list.data = []presets.Preset{
+		{Name: "Worked", Query: "status=pending-worked"},
+		{Name: "Mine", Query: "allocation=14", Default: true},
+	}
+
+	r, _ := http.NewRequest("GET", "/teams/work-in-progress/1", nil)
+	err := teamWorkInProgress(client, nil, "", testCaseworkTeams, store)(httptest.NewRecorder(), r)
+	assert.Equal(RedirectError("/teams/work-in-progress/1?allocation=14"), err)
+	assert.Equal(14, store.list.lastUserID)
+	assert.Equal(0, client.casesByTeam.count)
+}
+
+func TestGetTeamWorkInProgressDefaultPresetNotApplied(t *testing.T) {
+	testCases := map[string]string{
+		"query set":  "/teams/work-in-progress/1?page=1",
+		"other team": "/teams/work-in-progress/2",
+	}
+
+	for name, path := range testCases {
+		t.Run(name, func(t *testing.T) {
+			client := &mockTeamWorkInProgressClient{}
+			client.myDetails.data = sirius.MyDetails{
+				ID:    14,
+				Roles: []string{"Manager"},
+				Teams: []sirius.MyDetailsTeam{{ID: 1}},
+			}
+			client.teams.data = []sirius.Team{{ID: 1}, {ID: 2}}
+			client.casesByTeam.data = &sirius.CasesByTeam{}
+			store := &mockPresetStore{}
+			store.list.data = []presets.Preset{{Name: "Mine", Query: "allocation=14", Default: true}}
+			template := &mockTemplate{}
+
+			r, _ := http.NewRequest("GET", path, nil)
+			err := teamWorkInProgress(client, template, "", testCaseworkTeams, store)(httptest.NewRecorder(), r)
+			assert.Nil(t, err)
+
+			vars := template.lastVars.(teamWorkInProgressVars)
+			assert.Equal(t, []presetLink{{Name: "Mine", Query: "allocation=14", Default: true, Href: "?allocation=14"}}, vars.Presets)
+		})
+	}
+}
+
+func TestGetTeamWorkInProgressPresetsError(t *testing.T) {
+	expectedError := errors.New("oops")
+
+	client := &mockTeamWorkInProgressClient{}
+	client.myDetails.data = sirius.MyDetails{Roles: []string{"Manager"}}
+	client.teams.data = []sirius.Team{{ID: 1}}
+	store := &mockPresetStore{}
+	store.list.err = expectedError
+
+	r, _ := http.NewRequest("GET", "/teams/work-in-progress/1", nil)
+	err := teamWorkInProgress(client, nil, "", testCaseworkTeams, store)(httptest.NewRecorder(), r)
+	assert.Equal(t, expectedError, err)
+}
@@ -15,14 +15,15 @@ type UserAllCasesClient interface {
 }
 
 type userAllCasesVars struct {
-	Assignee   sirius.Assignee
-	Team       sirius.Team
-	Cases      []sirius.Case
-	Pagination *Pagination
-	XSRFToken  string
+	Assignee   sirius.Assignee `json:"assignee"`
+	Team       sirius.Team     `json:"team"`
+	Cases      []sirius.Case   `json:"cases"`
+	Pagination *Pagination     `json:"pagination"`
+	XSRFToken  string          `json:"-"`
+	Sort       Sort            `json:"sort"`
 }
 
-func userAllCases(client UserAllCasesClient, tmpl Template) Handler {
+func userAllCases(client UserAllCasesClient, tmpl Template, prefix string) Handler {
 	return func(w http.ResponseWriter, r *http.Request) error {
 		if r.Method != http.MethodGet {
 			return StatusError(http.StatusMethodNotAllowed)
@@ -50,7 +51,24 @@ func userAllCases(client UserAllCasesClient, tmpl Template) Handler {
 			return err
 		}
 
-		cases, pagination, err := client.CasesByAssignee(ctx, id, sirius.Criteria{}.Page(getPage(r)).Sort("receiptDate", sirius.Ascending))
+		chosen, err := caseSortSchema.Parse(r.URL.Query())
+		if err != nil {
+			return err
+		}
+
+		pageSize, err := getPageSize(w, r, prefix)
+		if err != nil {
+			return err
+		}
+
+		criteria := chosen
+		if !criteria.IsSorted() {
+			criteria = criteria.Sort("receiptDate", sirius.Ascending)
+		}
+
+		criteria = withPageSize(criteria.Page(getPage(r)), pageSize)
+
+		cases, pagination, err := client.CasesByAssignee(ctx, id, criteria)
 
 		if err != nil {
 			return err
@@ -65,8 +83,9 @@ func userAllCases(client UserAllCasesClient, tmpl Template) Handler {
 			Assignee:   assignee,
 			Team:       team,
 			Cases:      cases,
-			Pagination: newPagination(pagination),
+			Pagination: newPaginationWithQuery(pagination, caseSortSchema.Encode(chosen).Encode()),
 			XSRFToken:  ctx.XSRFToken,
+			Sort:       newSort(caseSortSchema, chosen, criteria),
 		}
 
 		return tmpl.ExecuteTemplate(w, "page", vars)
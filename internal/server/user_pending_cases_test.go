@@ -84,7 +84,7 @@ func TestGetUserPendingCases(t *testing.T) {
 	w := httptest.NewRecorder()
 	r, _ := http.NewRequest("GET", "/users/pending-cases/74", nil)
 
-	err := userPendingCases(client, template)(w, r)
+	err := userPendingCases(client, template, "")(w, r)
 	assert.Nil(err)
 
 	assert.Equal(1, client.myDetails.count)
@@ -105,6 +105,7 @@ func TestGetUserPendingCases(t *testing.T) {
 		Assignee:   client.user.data,
 		Cases:      client.casesByAssignee.data,
 		Pagination: newPagination(client.casesByAssignee.pagination),
+		Sort:       Sort{Field: "receiptDate", Order: "asc"},
 	}, template.lastVars)
 }
 
@@ -135,7 +136,7 @@ func TestGetUserPendingCasesPage(t *testing.T) {
 	w := httptest.NewRecorder()
 	r, _ := http.NewRequest("GET", "/users/pending-cases/74?page=4", nil)
 
-	err := userPendingCases(client, template)(w, r)
+	err := userPendingCases(client, template, "")(w, r)
 	assert.Nil(err)
 
 	assert.Equal(1, client.myDetails.count)
@@ -157,6 +158,7 @@ func TestGetUserPendingCasesPage(t *testing.T) {
 		Team:       client.user.data.Teams[0],
 		Cases:      client.casesByAssignee.data,
 		Pagination: newPagination(client.casesByAssignee.pagination),
+		Sort:       Sort{Field: "receiptDate", Order: "asc"},
 	}, template.lastVars)
 }
 
@@ -172,7 +174,7 @@ func TestGetUserPendingCasesForbidden(t *testing.T) {
 	w := httptest.NewRecorder()
 	r, _ := http.NewRequest("GET", "/users/pending-cases/74", nil)
 
-	err := userPendingCases(client, template)(w, r)
+	err := userPendingCases(client, template, "")(w, r)
 	assert.Equal(StatusError(http.StatusForbidden), err)
 
 	assert.Equal(1, client.myDetails.count)
@@ -194,7 +196,7 @@ func TestGetUserPendingCasesMyDetailsError(t *testing.T) {
 	w := httptest.NewRecorder()
 	r, _ := http.NewRequest("GET", "/users/pending-cases/74", nil)
 
-	err := userPendingCases(client, template)(w, r)
+	err := userPendingCases(client, template, "")(w, r)
 	assert.Equal(expectedError, err)
 
 	assert.Equal(1, client.myDetails.count)
@@ -219,7 +221,7 @@ func TestGetUserPendingCasesGetUserError(t *testing.T) {
 	w := httptest.NewRecorder()
 	r, _ := http.NewRequest("GET", "/users/pending-cases/74", nil)
 
-	err := userPendingCases(client, template)(w, r)
+	err := userPendingCases(client, template, "")(w, r)
 	assert.Equal(expectedError, err)
 
 	assert.Equal(1, client.myDetails.count)
@@ -251,7 +253,7 @@ func TestGetUserPendingCasesQueryError(t *testing.T) {
 	w := httptest.NewRecorder()
 	r, _ := http.NewRequest("GET", "/users/pending-cases/74", nil)
 
-	err := userPendingCases(client, template)(w, r)
+	err := userPendingCases(client, template, "")(w, r)
 	assert.Equal(expectedError, err)
 
 	assert.Equal(1, client.myDetails.count)
@@ -276,7 +278,7 @@ func TestBadMethodUserPendingCases(t *testing.T) {
 	w := httptest.NewRecorder()
 	r, _ := http.NewRequest("DELETE", "/users/pending-cases/74", nil)
 
-	err := userPendingCases(client, template)(w, r)
+	err := userPendingCases(client, template, "")(w, r)
 
 	assert.Equal(StatusError(http.StatusMethodNotAllowed), err)
 
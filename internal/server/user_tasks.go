@@ -1,6 +1,7 @@
 package server
 
 import (
+	"iter"
 	"net/http"
 	"strconv"
 	"strings"
@@ -9,20 +10,22 @@ import (
 )
 
 type UserTasksClient interface {
-	CasesWithOpenTasksByAssignee(sirius.Context, int, int) ([]sirius.Case, *sirius.Pagination, error)
+	CasesWithOpenTasksByAssignee(sirius.Context, int, sirius.Criteria) ([]sirius.Case, *sirius.Pagination, error)
 	MyDetails(sirius.Context) (sirius.MyDetails, error)
 	User(sirius.Context, int) (sirius.Assignee, error)
+	AllTasksByAssignee(sirius.Context, int, sirius.Criteria) iter.Seq2[sirius.Task, error]
 }
 
 type userTasksVars struct {
-	Assignee   sirius.Assignee
-	Team       sirius.Team
-	Cases      []sirius.Case
-	Pagination *Pagination
-	XSRFToken  string
+	Assignee   sirius.Assignee `json:"assignee"`
+	Team       sirius.Team     `json:"team"`
+	Cases      []sirius.Case   `json:"cases"`
+	Tasks      []sirius.Task   `json:"tasks"`
+	Pagination *Pagination     `json:"pagination"`
+	XSRFToken  string          `json:"-"`
 }
 
-func userTasks(client UserTasksClient, tmpl Template) Handler {
+func userTasks(client UserTasksClient, tmpl Template, prefix string) Handler {
 	return func(w http.ResponseWriter, r *http.Request) error {
 		if r.Method != http.MethodGet {
 			return StatusError(http.StatusMethodNotAllowed)
@@ -44,21 +47,36 @@ func userTasks(client UserTasksClient, tmpl Template) Handler {
 			return StatusError(http.StatusNotFound)
 		}
 
-		assignee, err := client.User(ctx, id)
-
+		pageSize, err := getPageSize(w, r, prefix)
 		if err != nil {
 			return err
 		}
 
-		cases, pagination, err := client.CasesWithOpenTasksByAssignee(ctx, id, getPage(r))
+		assignee, err := client.User(ctx, id)
+
 		if err != nil {
 			return err
 		}
 
+		cases, pagination, err := client.CasesWithOpenTasksByAssignee(ctx, id, withPageSize(sirius.Criteria{}.Page(getPage(r)), pageSize))
 		if err != nil {
 			return err
 		}
 
+		taskCriteria := sirius.Criteria{}.
+			Filter("status", sirius.TaskNotStarted).
+			Filter("status", sirius.TaskInProgress).
+			Sort("dueDate", sirius.Ascending)
+
+		var tasks []sirius.Task
+		for task, err := range client.AllTasksByAssignee(ctx, id, taskCriteria) {
+			if err != nil {
+				return err
+			}
+
+			tasks = append(tasks, task)
+		}
+
 		var team sirius.Team
 		if len(assignee.Teams) > 0 {
 			team = assignee.Teams[0]
@@ -68,6 +86,7 @@ func userTasks(client UserTasksClient, tmpl Template) Handler {
 			Assignee:   assignee,
 			Team:       team,
 			Cases:      cases,
+			Tasks:      tasks,
 			Pagination: newPagination(pagination),
 			XSRFToken:  ctx.XSRFToken,
 		}
@@ -2,6 +2,7 @@ package server
 
 import (
 	"errors"
+	"iter"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -25,13 +26,40 @@ type mockUserTasksClient struct {
 		err     error
 	}
 	casesWithOpenTasksByAssignee struct {
-		count      int
-		lastCtx    sirius.Context
-		lastId     int
-		lastPage   int
-		data       []sirius.Case
-		pagination *sirius.Pagination
-		err        error
+		count        int
+		lastCtx      sirius.Context
+		lastId       int
+		lastCriteria sirius.Criteria
+		data         []sirius.Case
+		pagination   *sirius.Pagination
+		err          error
+	}
+	allTasksByAssignee struct {
+		count        int
+		lastCtx      sirius.Context
+		lastId       int
+		lastCriteria sirius.Criteria
+		data         []sirius.Task
+		err          error
+	}
+}
+
+func (m *mockUserTasksClient) AllTasksByAssignee(ctx sirius.Context, id int, criteria sirius.Criteria) iter.Seq2[sirius.Task, error] {
+	m.allTasksByAssignee.count += 1
+	m.allTasksByAssignee.lastCtx = ctx
+	m.allTasksByAssignee.lastId = id
+	m.allTasksByAssignee.lastCriteria = criteria
+
+	return func(yield func(sirius.Task, error) bool) {
+		for _, task := range m.allTasksByAssignee.data {
+			if !yield(task, nil) {
+				return
+			}
+		}
+
+		if m.allTasksByAssignee.err != nil {
+			yield(sirius.Task{}, m.allTasksByAssignee.err)
+		}
 	}
 }
 
@@ -50,11 +78,11 @@ func (m *mockUserTasksClient) User(ctx sirius.Context, id int) (sirius.Assignee,
 	return m.user.data, m.user.err
 }
 
-func (m *mockUserTasksClient) CasesWithOpenTasksByAssignee(ctx sirius.Context, id, page int) ([]sirius.Case, *sirius.Pagination, error) {
+func (m *mockUserTasksClient) CasesWithOpenTasksByAssignee(ctx sirius.Context, id int, criteria sirius.Criteria) ([]sirius.Case, *sirius.Pagination, error) {
 	m.casesWithOpenTasksByAssignee.count += 1
 	m.casesWithOpenTasksByAssignee.lastCtx = ctx
 	m.casesWithOpenTasksByAssignee.lastId = id
-	m.casesWithOpenTasksByAssignee.lastPage = page
+	m.casesWithOpenTasksByAssignee.lastCriteria = criteria
 
 	return m.casesWithOpenTasksByAssignee.data, m.casesWithOpenTasksByAssignee.pagination, m.casesWithOpenTasksByAssignee.err
 }
@@ -79,12 +107,13 @@ func TestGetUserTasks(t *testing.T) {
 	client.casesWithOpenTasksByAssignee.pagination = &sirius.Pagination{
 		TotalItems: 20,
 	}
+	client.allTasksByAssignee.data = []sirius.Task{{ID: 36, Name: "Review"}}
 	template := &mockTemplate{}
 
 	w := httptest.NewRecorder()
 	r, _ := http.NewRequest("GET", "/users/tasks/74", nil)
 
-	err := userTasks(client, template)(w, r)
+	err := userTasks(client, template, "")(w, r)
 	assert.Nil(err)
 
 	assert.Equal(1, client.myDetails.count)
@@ -97,13 +126,19 @@ func TestGetUserTasks(t *testing.T) {
 	assert.Equal(1, client.casesWithOpenTasksByAssignee.count)
 	assert.Equal(getContext(r), client.casesWithOpenTasksByAssignee.lastCtx)
 	assert.Equal(74, client.casesWithOpenTasksByAssignee.lastId)
-	assert.Equal(1, client.casesWithOpenTasksByAssignee.lastPage)
+	assert.Equal(sirius.Criteria{}.Page(1), client.casesWithOpenTasksByAssignee.lastCriteria)
+
+	assert.Equal(1, client.allTasksByAssignee.count)
+	assert.Equal(getContext(r), client.allTasksByAssignee.lastCtx)
+	assert.Equal(74, client.allTasksByAssignee.lastId)
+	assert.Equal(sirius.Criteria{}.Filter("status", "Not started").Filter("status", "In progress").Sort("dueDate", sirius.Ascending), client.allTasksByAssignee.lastCriteria)
 
 	assert.Equal(1, template.count)
 	assert.Equal("page", template.lastName)
 	assert.Equal(userTasksVars{
 		Assignee:   client.user.data,
 		Cases:      client.casesWithOpenTasksByAssignee.data,
+		Tasks:      client.allTasksByAssignee.data,
 		Pagination: newPagination(client.casesWithOpenTasksByAssignee.pagination),
 	}, template.lastVars)
 }
@@ -135,7 +170,7 @@ func TestGetUserTasksPage(t *testing.T) {
 	w := httptest.NewRecorder()
 	r, _ := http.NewRequest("GET", "/users/tasks/74?page=4", nil)
 
-	err := userTasks(client, template)(w, r)
+	err := userTasks(client, template, "")(w, r)
 	assert.Nil(err)
 
 	assert.Equal(1, client.myDetails.count)
@@ -148,7 +183,7 @@ func TestGetUserTasksPage(t *testing.T) {
 	assert.Equal(1, client.casesWithOpenTasksByAssignee.count)
 	assert.Equal(getContext(r), client.casesWithOpenTasksByAssignee.lastCtx)
 	assert.Equal(74, client.casesWithOpenTasksByAssignee.lastId)
-	assert.Equal(4, client.casesWithOpenTasksByAssignee.lastPage)
+	assert.Equal(sirius.Criteria{}.Page(4), client.casesWithOpenTasksByAssignee.lastCriteria)
 
 	assert.Equal(1, template.count)
 	assert.Equal("page", template.lastName)
@@ -172,7 +207,7 @@ func TestGetUserTasksForbidden(t *testing.T) {
 	w := httptest.NewRecorder()
 	r, _ := http.NewRequest("GET", "/users/tasks/74", nil)
 
-	err := userTasks(client, template)(w, r)
+	err := userTasks(client, template, "")(w, r)
 	assert.Equal(StatusError(http.StatusForbidden), err)
 
 	assert.Equal(1, client.myDetails.count)
@@ -194,7 +229,7 @@ func TestGetUserTasksMyDetailsError(t *testing.T) {
 	w := httptest.NewRecorder()
 	r, _ := http.NewRequest("GET", "/users/tasks/74", nil)
 
-	err := userTasks(client, template)(w, r)
+	err := userTasks(client, template, "")(w, r)
 	assert.Equal(expectedError, err)
 
 	assert.Equal(1, client.myDetails.count)
@@ -219,7 +254,7 @@ func TestGetUserTasksGetUserError(t *testing.T) {
 	w := httptest.NewRecorder()
 	r, _ := http.NewRequest("GET", "/users/tasks/74", nil)
 
-	err := userTasks(client, template)(w, r)
+	err := userTasks(client, template, "")(w, r)
 	assert.Equal(expectedError, err)
 
 	assert.Equal(1, client.myDetails.count)
@@ -251,7 +286,7 @@ func TestGetUserTasksQueryError(t *testing.T) {
 	w := httptest.NewRecorder()
 	r, _ := http.NewRequest("GET", "/users/tasks/74", nil)
 
-	err := userTasks(client, template)(w, r)
+	err := userTasks(client, template, "")(w, r)
 	assert.Equal(expectedError, err)
 
 	assert.Equal(1, client.myDetails.count)
@@ -264,7 +299,34 @@ func TestGetUserTasksQueryError(t *testing.T) {
 	assert.Equal(1, client.casesWithOpenTasksByAssignee.count)
 	assert.Equal(getContext(r), client.casesWithOpenTasksByAssignee.lastCtx)
 	assert.Equal(74, client.casesWithOpenTasksByAssignee.lastId)
-	assert.Equal(1, client.casesWithOpenTasksByAssignee.lastPage)
+	assert.Equal(sirius.Criteria{}.Page(1), client.casesWithOpenTasksByAssignee.lastCriteria)
+	assert.Equal(0, client.allTasksByAssignee.count)
+}
+
+func TestGetUserTasksTasksError(t *testing.T) {
+	assert := assert.New(t)
+
+	expectedError := errors.New("oops")
+
+	client := &mockUserTasksClient{}
+	client.myDetails.data = sirius.MyDetails{
+		Roles: []string{"Manager"},
+	}
+	client.user.data = sirius.Assignee{
+		ID:          74,
+		DisplayName: "Elfriede Giesing",
+	}
+	client.allTasksByAssignee.err = expectedError
+	template := &mockTemplate{}
+
+	w := httptest.NewRecorder()
+	r, _ := http.NewRequest("GET", "/users/tasks/74", nil)
+
+	err := userTasks(client, template, "")(w, r)
+	assert.Equal(expectedError, err)
+
+	assert.Equal(1, client.allTasksByAssignee.count)
+	assert.Equal(0, template.count)
 }
 
 func TestBadMethodUserTasks(t *testing.T) {
@@ -276,7 +338,7 @@ func TestBadMethodUserTasks(t *testing.T) {
 	w := httptest.NewRecorder()
 	r, _ := http.NewRequest("DELETE", "/users/tasks/74", nil)
 
-	err := userTasks(client, template)(w, r)
+	err := userTasks(client, template, "")(w, r)
 
 	assert.Equal(StatusError(http.StatusMethodNotAllowed), err)
 
@@ -0,0 +1,57 @@
+package server
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWriteXLSX(t *testing.T) {
+	assert := assert.New(t)
+
+	var buf bytes.Buffer
+	xw, err := newXLSXWriter(&buf, "Cases")
+	assert.Nil(err)
+	assert.Nil(xw.Write([]any{"Name", "Age"}))
+	assert.Nil(xw.Write([]any{"A & B <C>", 3}))
+	assert.Nil(xw.Write([]any{"=HYPERLINK(\"http://example.com\")", 4}))
+	assert.Nil(xw.Write([]any{"-1", "@Wilma"}))
+	assert.Nil(xw.Close())
+
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	assert.Nil(err)
+
+	files := map[string]string{}
+	for _, f := range zr.File {
+		rc, _ := f.Open()
+		data, _ := io.ReadAll(rc)
+		rc.Close()
+		files[f.Name] = string(data)
+	}
+
+	assert.Contains(files, "[Content_Types].xml")
+	assert.Contains(files, "_rels/.rels")
+	assert.Contains(files, "xl/_rels/workbook.xml.rels")
+	assert.Contains(files["xl/workbook.xml"], `<sheet name="Cases" sheetId="1" r:id="rId1"/>`)
+
+	sheet := files["xl/worksheets/sheet1.xml"]
+	assert.Contains(sheet, `<c r="A1" t="inlineStr"><is><t xml:space="preserve">Name</t></is></c>`)
+	assert.Contains(sheet, `<c r="A2" t="inlineStr"><is><t xml:space="preserve">A &amp; B &lt;C&gt;</t></is></c>`)
+	assert.Contains(sheet, `<c r="B2"><v>3</v></c>`)
+	assert.Contains(sheet, `<c r="A3" t="inlineStr"><is><t xml:space="preserve">=HYPERLINK(&#34;http://example.com&#34;)</t></is></c>`)
+	assert.Contains(sheet, `<c r="A4" t="inlineStr"><is><t xml:space="preserve">-1</t></is></c>`)
+	assert.Contains(sheet, `<c r="B4" t="inlineStr"><is><t xml:space="preserve">@Wilma</t></is></c>`)
+	assert.True(strings.HasSuffix(sheet, `</row></sheetData></worksheet>`))
+}
+
+func TestXLSXColumn(t *testing.T) {
+	assert.Equal(t, "A", xlsxColumn(0))
+	assert.Equal(t, "Z", xlsxColumn(25))
+	assert.Equal(t, "AA", xlsxColumn(26))
+	assert.Equal(t, "AZ", xlsxColumn(51))
+	assert.Equal(t, "BA", xlsxColumn(52))
+}
@@ -3,34 +3,103 @@ package sirius
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
+	"slices"
 	"strconv"
 	"strings"
 )
 
+const defaultAssignChunkSize = 20
+
 type assignRequest struct {
 	Data []assignRequestItem `json:"data"`
 }
 
 type assignRequestItem struct {
 	AssigneeID int    `json:"assigneeId"`
-	CaseType   string `json:"caseType"`
+	CaseType   string `json:"caseType,omitempty"`
 	ID         int    `json:"id"`
 }
 
-func (c *Client) Assign(ctx Context, cases []int, assignee int) error {
+// AssignResult is the outcome of assigning a single case or task. Err is nil
+// if it was assigned.
+type AssignResult struct {
+	ID  int
+	Err error
+}
+
+// SetAssignChunkSize sets how many cases Assign sends to Sirius in each
+// request.
+func (c *Client) SetAssignChunkSize(size int) {
+	if size > 0 {
+		c.assignChunkSize = size
+	}
+}
+
+// Assign assigns the cases to the assignee, sending them in chunks so that
+// large selections do not exceed URL length limits. If Sirius rejects a chunk
+// each of its cases is tried on its own, so that the result for every case
+// can be reported. An error is only returned if the session is no longer
+// valid, in which case no further cases are attempted.
+func (c *Client) Assign(ctx Context, cases []int, assignee int) ([]AssignResult, error) {
+	return c.assignAll(ctx, "cases", cases, assignee)
+}
+
+// AssignTasks assigns the tasks to the assignee, in the same way as Assign
+// does for cases.
+func (c *Client) AssignTasks(ctx Context, tasks []int, assignee int) ([]AssignResult, error) {
+	return c.assignAll(ctx, "tasks", tasks, assignee)
+}
+
+func (c *Client) assignAll(ctx Context, kind string, ids []int, assignee int) ([]AssignResult, error) {
+	results := make([]AssignResult, 0, len(ids))
+
+	for chunk := range slices.Chunk(ids, c.assignChunkSize) {
+		err := c.assignChunk(ctx, kind, chunk, assignee)
+		if err == ErrUnauthorized {
+			return results, err
+		}
+
+		var statusError *StatusError
+		if len(chunk) > 1 && errors.As(err, &statusError) && statusError.Code < http.StatusInternalServerError {
+			for _, id := range chunk {
+				err := c.assignChunk(ctx, kind, []int{id}, assignee)
+				if err == ErrUnauthorized {
+					return results, err
+				}
+
+				results = append(results, AssignResult{ID: id, Err: err})
+			}
+
+			continue
+		}
+
+		for _, id := range chunk {
+			results = append(results, AssignResult{ID: id, Err: err})
+		}
+	}
+
+	return results, nil
+}
+
+func (c *Client) assignChunk(ctx Context, kind string, ids []int, assignee int) error {
 	var data assignRequest
-	caseList := make([]string, len(cases))
+	idList := make([]string, len(ids))
 
-	for i, c := range cases {
-		caseList[i] = strconv.Itoa(c)
+	for i, id := range ids {
+		idList[i] = strconv.Itoa(id)
 
-		data.Data = append(data.Data, assignRequestItem{
+		item := assignRequestItem{
 			AssigneeID: assignee,
-			CaseType:   "LPA",
-			ID:         c,
-		})
+			ID:         id,
+		}
+		if kind == "cases" {
+			item.CaseType = "LPA"
+		}
+
+		data.Data = append(data.Data, item)
 	}
 
 	var buf bytes.Buffer
@@ -38,14 +107,14 @@ func (c *Client) Assign(ctx Context, cases []int, assignee int) error {
 		return err
 	}
 
-	url := fmt.Sprintf("/lpa-api/v1/users/%d/cases/%s", assignee, strings.Join(caseList, "+"))
+	url := fmt.Sprintf("/lpa-api/v1/users/%d/%s/%s", assignee, kind, strings.Join(idList, "+"))
 
 	req, err := c.newRequest(ctx, http.MethodPut, url, &buf)
 	if err != nil {
 		return err
 	}
 
-	resp, err := c.http.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return err
 	}
@@ -2,8 +2,12 @@ package sirius
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
 	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/pact-foundation/pact-go/v2/consumer"
@@ -54,8 +58,9 @@ func TestAssign(t *testing.T) {
 			assert.Nil(t, pact.ExecuteTest(t, func(config consumer.MockServerConfig) error {
 				client, _ := NewClient(http.DefaultClient, fmt.Sprintf("http://127.0.0.1:%d", config.Port))
 
-				err := client.Assign(Context{Context: context.Background()}, []int{58}, 47)
+				results, err := client.Assign(Context{Context: context.Background()}, []int{58}, 47)
 				assert.Equal(t, tc.expectedError, err)
+				assert.Equal(t, []AssignResult{{ID: 58}}, results)
 				return nil
 			}))
 		})
@@ -68,10 +73,196 @@ func TestAssignStatusError(t *testing.T) {
 
 	client, _ := NewClient(http.DefaultClient, s.URL)
 
-	err := client.Assign(Context{Context: context.Background()}, []int{1}, 47)
-	assert.Equal(t, &StatusError{
+	results, err := client.Assign(Context{Context: context.Background()}, []int{1}, 47)
+	assert.Nil(t, err)
+	assert.Equal(t, []AssignResult{{ID: 1, Err: &StatusError{
 		Code:   http.StatusTeapot,
 		URL:    s.URL + "/lpa-api/v1/users/47/cases/1",
 		Method: http.MethodPut,
-	}, err)
+	}}}, results)
+}
+
+// assignServer accepts assignments except for the cases in reject, which
+// cause the whole request to fail with a 400.
+func assignServer(reject map[string]bool, paths *[]string) *httptest.Server {
+	return httptest.NewServer(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			*paths = append(*paths, r.URL.Path)
+
+			ids := strings.Split(strings.TrimPrefix(r.URL.Path, "/lpa-api/v1/users/47/cases/"), "+")
+			for _, id := range ids {
+				if reject[id] {
+					w.Header().Set("Content-Type", "application/problem+json")
+					w.WriteHeader(http.StatusBadRequest)
+					_, _ = fmt.Fprintf(w, `{"title":"Bad request","detail":"Case %s cannot be reassigned"}`, id)
+					return
+				}
+			}
+
+			w.WriteHeader(http.StatusOK)
+		}),
+	)
+}
+
+func TestAssignChunks(t *testing.T) {
+	assert := assert.New(t)
+
+	var paths []string
+	s := assignServer(nil, &paths)
+	defer s.Close()
+
+	client, _ := NewClient(http.DefaultClient, s.URL)
+	client.SetAssignChunkSize(2)
+
+	results, err := client.Assign(Context{Context: context.Background()}, []int{1, 2, 3, 4, 5}, 47)
+	assert.Nil(err)
+	assert.Equal([]AssignResult{{ID: 1}, {ID: 2}, {ID: 3}, {ID: 4}, {ID: 5}}, results)
+	assert.Equal([]string{
+		"/lpa-api/v1/users/47/cases/1+2",
+		"/lpa-api/v1/users/47/cases/3+4",
+		"/lpa-api/v1/users/47/cases/5",
+	}, paths)
+}
+
+func TestAssignChunkRejected(t *testing.T) {
+	assert := assert.New(t)
+
+	var paths []string
+	s := assignServer(map[string]bool{"3": true}, &paths)
+	defer s.Close()
+
+	client, _ := NewClient(http.DefaultClient, s.URL)
+	client.SetAssignChunkSize(2)
+
+	results, err := client.Assign(Context{Context: context.Background()}, []int{1, 2, 3, 4}, 47)
+	assert.Nil(err)
+	assert.Len(results, 4)
+	assert.Nil(results[0].Err)
+	assert.Nil(results[1].Err)
+	assert.Nil(results[3].Err)
+
+	var statusError *StatusError
+	assert.True(errors.As(results[2].Err, &statusError))
+	assert.Equal("Case 3 cannot be reassigned", statusError.Detail())
+
+	assert.Equal([]string{
+		"/lpa-api/v1/users/47/cases/1+2",
+		"/lpa-api/v1/users/47/cases/3+4",
+		"/lpa-api/v1/users/47/cases/3",
+		"/lpa-api/v1/users/47/cases/4",
+	}, paths)
+}
+
+func TestAssignServerErrorFailsChunk(t *testing.T) {
+	assert := assert.New(t)
+
+	var paths []string
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer s.Close()
+
+	client, _ := NewClient(http.DefaultClient, s.URL)
+
+	results, err := client.Assign(Context{Context: context.Background()}, []int{1, 2}, 47)
+	assert.Nil(err)
+	assert.Len(results, 2)
+	assert.IsType(&StatusError{}, results[0].Err)
+	assert.Equal(results[0].Err, results[1].Err)
+	assert.Len(paths, 1)
+}
+
+func TestAssignUnauthorized(t *testing.T) {
+	assert := assert.New(t)
+
+	var paths []string
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer s.Close()
+
+	client, _ := NewClient(http.DefaultClient, s.URL)
+	client.SetAssignChunkSize(1)
+
+	results, err := client.Assign(Context{Context: context.Background()}, []int{1, 2}, 47)
+	assert.Equal(ErrUnauthorized, err)
+	assert.Empty(results)
+	assert.Len(paths, 1)
+}
+
+func TestAssignTasks(t *testing.T) {
+	pact, err := newPact()
+	assert.NoError(t, err)
+
+	testCases := []struct {
+		name          string
+		setup         func()
+		expectedError error
+	}{
+		{
+			name: "OK",
+			setup: func() {
+				pact.
+					AddInteraction().
+					Given("I have an open task assigned").
+					UponReceiving("A request to reassign a task").
+					WithCompleteRequest(consumer.Request{
+						Method: http.MethodPut,
+						Path:   matchers.String("/lpa-api/v1/users/47/tasks/36"),
+						Body: matchers.Like(map[string]interface{}{
+							"data": matchers.EachLike(map[string]interface{}{
+								"assigneeId": matchers.Like(47),
+								"id":         matchers.Like(36),
+							}, 1),
+						}),
+					}).
+					WithCompleteResponse(consumer.Response{
+						Status:  http.StatusOK,
+						Headers: matchers.MapMatcher{"Content-Type": matchers.String("application/json")},
+					})
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.setup()
+			assert.Nil(t, pact.ExecuteTest(t, func(config consumer.MockServerConfig) error {
+				client, _ := NewClient(http.DefaultClient, fmt.Sprintf("http://127.0.0.1:%d", config.Port))
+
+				results, err := client.AssignTasks(Context{Context: context.Background()}, []int{36}, 47)
+				assert.Equal(t, tc.expectedError, err)
+				assert.Equal(t, []AssignResult{{ID: 36}}, results)
+				return nil
+			}))
+		})
+	}
+}
+
+func TestAssignTasksChunks(t *testing.T) {
+	assert := assert.New(t)
+
+	var paths []string
+	var bodies []string
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		paths = append(paths, r.URL.Path)
+		bodies = append(bodies, strings.TrimSpace(string(body)))
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer s.Close()
+
+	client, _ := NewClient(http.DefaultClient, s.URL)
+	client.SetAssignChunkSize(2)
+
+	results, err := client.AssignTasks(Context{Context: context.Background()}, []int{1, 2, 3}, 47)
+	assert.Nil(err)
+	assert.Equal([]AssignResult{{ID: 1}, {ID: 2}, {ID: 3}}, results)
+	assert.Equal([]string{
+		"/lpa-api/v1/users/47/tasks/1+2",
+		"/lpa-api/v1/users/47/tasks/3",
+	}, paths)
+	assert.Equal(`{"data":[{"assigneeId":47,"id":3}]}`, bodies[1])
 }
@@ -0,0 +1,136 @@
+package sirius
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const ErrSiriusUnavailable ClientError = "Sirius is unavailable"
+
+// UnavailableError matches ErrSiriusUnavailable with errors.Is.
+type UnavailableError struct {
+	RetryAfter time.Duration
+}
+
+func (e UnavailableError) Error() string {
+	return string(ErrSiriusUnavailable)
+}
+
+func (e UnavailableError) Is(target error) bool {
+	return target == ErrSiriusUnavailable
+}
+
+type CircuitState string
+
+const (
+	CircuitClosed   CircuitState = "closed"
+	CircuitOpen     CircuitState = "open"
+	CircuitHalfOpen CircuitState = "half-open"
+)
+
+type circuitBreaker struct {
+	threshold int
+	cooldown  time.Duration
+	now       func() time.Time
+
+	mu       sync.Mutex
+	failures int
+	openedAt time.Time
+	probing  bool
+}
+
+func newCircuitBreaker(threshold int, cooldown time.Duration) *circuitBreaker {
+	return &circuitBreaker{
+		threshold: threshold,
+		cooldown:  cooldown,
+		now:       time.Now,
+	}
+}
+
+func (b *circuitBreaker) state() CircuitState {
+	if b.failures < b.threshold {
+		return CircuitClosed
+	}
+
+	if b.now().Sub(b.openedAt) < b.cooldown {
+		return CircuitOpen
+	}
+
+	return CircuitHalfOpen
+}
+
+func (b *circuitBreaker) State() CircuitState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.state()
+}
+
+// allow reports whether the request is the probe sent once the cooldown has
+// passed. Only one probe is let through at a time.
+func (b *circuitBreaker) allow() (bool, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state() {
+	case CircuitOpen:
+		return false, UnavailableError{RetryAfter: b.cooldown - b.now().Sub(b.openedAt)}
+	case CircuitHalfOpen:
+		if b.probing {
+			return false, UnavailableError{RetryAfter: time.Second}
+		}
+		b.probing = true
+		return true, nil
+	}
+
+	return false, nil
+}
+
+// record only lets the probe decide whether a breaker that has opened closes
+// again, as other requests were sent before it opened.
+func (b *circuitBreaker) record(probe bool, resp *http.Response, err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if probe {
+		b.probing = false
+	}
+
+	if errors.Is(err, context.Canceled) {
+		return
+	}
+
+	failed := err != nil || (resp != nil && isRetryableStatus(resp.StatusCode))
+
+	if probe {
+		if failed {
+			b.failures = b.threshold
+			b.openedAt = b.now()
+		} else {
+			b.failures = 0
+		}
+		return
+	}
+
+	if b.state() != CircuitClosed {
+		return
+	}
+
+	if !failed {
+		b.failures = 0
+		return
+	}
+
+	b.failures++
+	if b.failures == b.threshold {
+		b.openedAt = b.now()
+	}
+}
+
+// CircuitState reports whether requests to Sirius are currently being attempted.
+func (c *Client) CircuitState() CircuitState {
+	return c.breaker.State()
+}
@@ -0,0 +1,184 @@
+package sirius
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnavailableError(t *testing.T) {
+	err := UnavailableError{RetryAfter: time.Minute}
+
+	assert.Equal(t, "Sirius is unavailable", err.Error())
+	assert.True(t, errors.Is(err, ErrSiriusUnavailable))
+	assert.False(t, errors.Is(err, ErrUnauthorized))
+}
+
+func TestCircuitBreaker(t *testing.T) {
+	assert := assert.New(t)
+
+	now := time.Date(2021, 5, 12, 9, 0, 0, 0, time.UTC)
+	breaker := newCircuitBreaker(2, time.Minute)
+	breaker.now = func() time.Time { return now }
+
+	unavailable := &http.Response{StatusCode: http.StatusServiceUnavailable}
+	ok := &http.Response{StatusCode: http.StatusOK}
+
+	breaker.record(false, unavailable, nil)
+	assert.Equal(CircuitClosed, breaker.State())
+	probe, err := breaker.allow()
+	assert.False(probe)
+	assert.Nil(err)
+
+	breaker.record(false, ok, nil)
+	breaker.record(false, unavailable, nil)
+	assert.Equal(CircuitClosed, breaker.State())
+
+	breaker.record(false, nil, errors.New("connection refused"))
+	assert.Equal(CircuitOpen, breaker.State())
+
+	now = now.Add(20 * time.Second)
+	_, err = breaker.allow()
+	assert.Equal(UnavailableError{RetryAfter: 40 * time.Second}, err)
+
+	now = now.Add(40 * time.Second)
+	assert.Equal(CircuitHalfOpen, breaker.State())
+	probe, err = breaker.allow()
+	assert.True(probe)
+	assert.Nil(err)
+	_, err = breaker.allow()
+	assert.Equal(UnavailableError{RetryAfter: time.Second}, err)
+
+	breaker.record(true, unavailable, nil)
+	assert.Equal(CircuitOpen, breaker.State())
+	_, err = breaker.allow()
+	assert.Equal(UnavailableError{RetryAfter: time.Minute}, err)
+
+	now = now.Add(time.Minute)
+	probe, err = breaker.allow()
+	assert.True(probe)
+	assert.Nil(err)
+	breaker.record(true, ok, nil)
+	assert.Equal(CircuitClosed, breaker.State())
+	probe, err = breaker.allow()
+	assert.False(probe)
+	assert.Nil(err)
+}
+
+func TestCircuitBreakerHalfOpenAdmitsOneProbe(t *testing.T) {
+	assert := assert.New(t)
+
+	now := time.Date(2021, 5, 12, 9, 0, 0, 0, time.UTC)
+	breaker := newCircuitBreaker(1, time.Minute)
+	breaker.now = func() time.Time { return now }
+
+	breaker.record(false, nil, errors.New("connection refused"))
+	now = now.Add(time.Minute)
+
+	var allowed atomic.Int32
+	var wg sync.WaitGroup
+	for range 50 {
+		wg.Go(func() {
+			if probe, err := breaker.allow(); err == nil {
+				assert.True(probe)
+				allowed.Add(1)
+			} else {
+				assert.ErrorIs(err, ErrSiriusUnavailable)
+			}
+		})
+	}
+	wg.Wait()
+
+	assert.Equal(int32(1), allowed.Load())
+
+	breaker.record(true, nil, errors.New("connection refused"))
+	assert.Equal(CircuitOpen, breaker.State())
+}
+
+func TestCircuitBreakerIgnoresEarlierRequestsWhileProbing(t *testing.T) {
+	assert := assert.New(t)
+
+	now := time.Date(2021, 5, 12, 9, 0, 0, 0, time.UTC)
+	breaker := newCircuitBreaker(1, time.Minute)
+	breaker.now = func() time.Time { return now }
+
+	breaker.record(false, nil, errors.New("connection refused"))
+	now = now.Add(time.Minute)
+
+	probe, err := breaker.allow()
+	assert.True(probe)
+	assert.Nil(err)
+
+	breaker.record(false, nil, errors.New("connection refused"))
+	assert.Equal(CircuitHalfOpen, breaker.State())
+	_, err = breaker.allow()
+	assert.Equal(UnavailableError{RetryAfter: time.Second}, err)
+
+	breaker.record(false, &http.Response{StatusCode: http.StatusOK}, nil)
+	assert.Equal(CircuitHalfOpen, breaker.State())
+
+	breaker.record(true, &http.Response{StatusCode: http.StatusOK}, nil)
+	assert.Equal(CircuitClosed, breaker.State())
+}
+
+func TestCircuitBreakerCancelledProbe(t *testing.T) {
+	assert := assert.New(t)
+
+	now := time.Date(2021, 5, 12, 9, 0, 0, 0, time.UTC)
+	breaker := newCircuitBreaker(1, time.Minute)
+	breaker.now = func() time.Time { return now }
+
+	breaker.record(false, nil, errors.New("connection refused"))
+	now = now.Add(time.Minute)
+
+	probe, err := breaker.allow()
+	assert.True(probe)
+	assert.Nil(err)
+	breaker.record(true, nil, context.Canceled)
+
+	assert.Equal(CircuitHalfOpen, breaker.State())
+	probe, err = breaker.allow()
+	assert.True(probe)
+	assert.Nil(err)
+}
+
+func TestCircuitBreakerIgnoresClientErrors(t *testing.T) {
+	assert := assert.New(t)
+
+	breaker := newCircuitBreaker(1, time.Minute)
+
+	breaker.record(false, &http.Response{StatusCode: http.StatusNotFound}, nil)
+	breaker.record(false, &http.Response{StatusCode: http.StatusUnauthorized}, nil)
+	breaker.record(false, nil, context.Canceled)
+
+	assert.Equal(CircuitClosed, breaker.State())
+}
+
+func TestClientCircuitBreaker(t *testing.T) {
+	s, hits := flakyServer(5, http.StatusServiceUnavailable)
+	defer s.Close()
+
+	client := newRetryingClient(s.URL)
+	client.retry.maxAttempts = 1
+	client.breaker = newCircuitBreaker(2, time.Minute)
+
+	ctx := Context{Context: context.Background()}
+
+	_, err := client.MyDetails(ctx)
+	assert.IsType(t, &StatusError{}, err)
+	_, err = client.MyDetails(ctx)
+	assert.IsType(t, &StatusError{}, err)
+
+	_, err = client.MyDetails(ctx)
+	assert.ErrorIs(t, err, ErrSiriusUnavailable)
+	assert.ErrorIs(t, client.MarkWorked(ctx, 1), ErrSiriusUnavailable)
+
+	assert.Equal(t, int32(2), *hits)
+	assert.Equal(t, CircuitOpen, client.CircuitState())
+}
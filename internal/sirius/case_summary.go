@@ -0,0 +1,52 @@
+package sirius
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// CaseSummary is the fuller view of a case that Sirius gives when it is
+// requested by ID.
+type CaseSummary struct {
+	Case
+	Attorneys     []Person   `json:"attorneys"`
+	Correspondent *Person    `json:"correspondent"`
+	WorkedDate    SiriusDate `json:"workedDate"`
+}
+
+type Person struct {
+	ID        int    `json:"id"`
+	Uid       string `json:"uId"`
+	Firstname string `json:"firstname"`
+	Surname   string `json:"surname"`
+}
+
+func (p Person) DisplayName() string {
+	return p.Firstname + " " + p.Surname
+}
+
+func (c *Client) CaseSummary(ctx Context, id int) (CaseSummary, error) {
+	req, err := c.newRequest(ctx, http.MethodGet, fmt.Sprintf("/lpa-api/v1/cases/%d", id), nil)
+	if err != nil {
+		return CaseSummary{}, err
+	}
+
+	resp, err := c.do(req)
+	if err != nil {
+		return CaseSummary{}, err
+	}
+	defer resp.Body.Close() //nolint:errcheck // no need to check error when closing body
+
+	if resp.StatusCode == http.StatusUnauthorized {
+		return CaseSummary{}, ErrUnauthorized
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return CaseSummary{}, newStatusError(resp)
+	}
+
+	var v CaseSummary
+	err = json.NewDecoder(resp.Body).Decode(&v)
+	return v, err
+}
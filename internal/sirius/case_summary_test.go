@@ -0,0 +1,124 @@
+package sirius
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/pact-foundation/pact-go/v2/consumer"
+	"github.com/pact-foundation/pact-go/v2/matchers"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCaseSummary(t *testing.T) {
+	pact, err := newPact()
+	assert.NoError(t, err)
+
+	testCases := []struct {
+		name            string
+		setup           func()
+		expectedSummary CaseSummary
+		expectedError   error
+	}{
+		{
+			name: "OK",
+			setup: func() {
+				pact.
+					AddInteraction().
+					Given("I have a pending case assigned").
+					UponReceiving("A request for a case summary").
+					WithCompleteRequest(consumer.Request{
+						Method: http.MethodGet,
+						Path:   matchers.String("/lpa-api/v1/cases/36"),
+					}).
+					WithCompleteResponse(consumer.Response{
+						Status:  http.StatusOK,
+						Headers: matchers.MapMatcher{"Content-Type": matchers.String("application/json")},
+						Body: matchers.Like(map[string]interface{}{
+							"id":  matchers.Like(36),
+							"uId": matchers.Term("7000-8548-8461", `\d{4}-\d{4}-\d{4}`),
+							"donor": matchers.Like(map[string]interface{}{
+								"id":        matchers.Like(23),
+								"uId":       matchers.Term("7000-5382-4438", `\d{4}-\d{4}-\d{4}`),
+								"firstname": matchers.Like("Adrian"),
+								"surname":   matchers.Like("Kurkjian"),
+							}),
+							"caseSubtype": matchers.Term("pfa", "hw|pfa"),
+							"receiptDate": matchers.Term("10/03/2021", `^\d{1,2}/\d{1,2}/\d{4}$`),
+							"status":      matchers.Like("Pending"),
+							"attorneys": matchers.EachLike(map[string]interface{}{
+								"id":        matchers.Like(24),
+								"uId":       matchers.Term("7000-1234-5678", `\d{4}-\d{4}-\d{4}`),
+								"firstname": matchers.Like("Sharon"),
+								"surname":   matchers.Like("Kurkjian"),
+							}, 1),
+							"correspondent": matchers.Like(map[string]interface{}{
+								"id":        matchers.Like(25),
+								"firstname": matchers.Like("Tom"),
+								"surname":   matchers.Like("Solicitor"),
+							}),
+							"workedDate": matchers.Term("12/03/2021", `^\d{1,2}/\d{1,2}/\d{4}$`),
+						}),
+					})
+			},
+			expectedSummary: CaseSummary{
+				Case: Case{
+					ID:  36,
+					Uid: "7000-8548-8461",
+					Donor: Donor{
+						ID:        23,
+						Uid:       "7000-5382-4438",
+						Firstname: "Adrian",
+						Surname:   "Kurkjian",
+					},
+					SubType:     "pfa",
+					ReceiptDate: SiriusDate{time.Date(2021, 3, 10, 0, 0, 0, 0, time.UTC)},
+					Status:      "Pending",
+				},
+				Attorneys: []Person{{
+					ID:        24,
+					Uid:       "7000-1234-5678",
+					Firstname: "Sharon",
+					Surname:   "Kurkjian",
+				}},
+				Correspondent: &Person{
+					ID:        25,
+					Firstname: "Tom",
+					Surname:   "Solicitor",
+				},
+				WorkedDate: SiriusDate{time.Date(2021, 3, 12, 0, 0, 0, 0, time.UTC)},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.setup()
+
+			assert.Nil(t, pact.ExecuteTest(t, func(config consumer.MockServerConfig) error {
+				client, _ := NewClient(http.DefaultClient, fmt.Sprintf("http://127.0.0.1:%d", config.Port))
+
+				summary, err := client.CaseSummary(Context{Context: context.Background()}, 36)
+				assert.Equal(t, tc.expectedSummary, summary)
+				assert.Equal(t, tc.expectedError, err)
+				return nil
+			}))
+		})
+	}
+}
+
+func TestCaseSummaryStatusError(t *testing.T) {
+	s := teapotServer()
+	defer s.Close()
+
+	client, _ := NewClient(http.DefaultClient, s.URL)
+
+	_, err := client.CaseSummary(Context{Context: context.Background()}, 36)
+	assert.Equal(t, &StatusError{
+		Code:   http.StatusTeapot,
+		URL:    s.URL + "/lpa-api/v1/cases/36",
+		Method: http.MethodGet,
+	}, err)
+}
@@ -45,7 +45,7 @@ func (c *Client) CasesByAssignee(ctx Context, id int, criteria Criteria) ([]Case
 		return nil, nil, err
 	}
 
-	resp, err := c.http.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, nil, err
 	}
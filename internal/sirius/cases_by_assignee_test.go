@@ -299,6 +299,97 @@ func TestCasesByAssignee(t *testing.T) {
 	}
 }
 
+func TestCasesByAssigneeFilters(t *testing.T) {
+	pact, err := newPact()
+
+	assert.NoError(t, err)
+
+	testCases := []struct {
+		name     string
+		criteria Criteria
+		filter   string
+	}{
+		{
+			name:     "received from",
+			criteria: Criteria{}.Filter("date-from", "2021-05-01"),
+			filter:   "date-from:2021-05-01",
+		},
+		{
+			name:     "received to",
+			criteria: Criteria{}.Filter("date-to", "2021-05-31"),
+			filter:   "date-to:2021-05-31",
+		},
+		{
+			name:     "LPA type",
+			criteria: Criteria{}.Filter("lpa-type", "hw"),
+			filter:   "lpa-type:hw",
+		},
+		{
+			name:     "case subtype",
+			criteria: Criteria{}.Filter("caseSubtype", "hw").Filter("caseSubtype", "pfa"),
+			filter:   "caseSubtype:hw,caseSubtype:pfa",
+		},
+		{
+			name:     "donor surname initial",
+			criteria: Criteria{}.Filter("surname-initial", "R"),
+			filter:   "surname-initial:R",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			pact.
+				AddInteraction().
+				Given("I have a pending case assigned").
+				UponReceiving("A request to get my pending cases filtered by " + tc.name).
+				WithCompleteRequest(consumer.Request{
+					Method: http.MethodGet,
+					Path:   matchers.String("/lpa-api/v1/assignees/47/cases"),
+					Query: matchers.MapMatcher{
+						"page":   matchers.String("1"),
+						"filter": matchers.String(tc.filter + ",status:Pending,caseType:lpa,active:true"),
+						"sort":   matchers.String("receiptDate:asc"),
+					},
+				}).
+				WithCompleteResponse(consumer.Response{
+					Status:  http.StatusOK,
+					Headers: matchers.MapMatcher{"Content-Type": matchers.String("application/json")},
+					Body: matchers.Like(map[string]interface{}{
+						"total": matchers.Like(1),
+						"limit": matchers.Like(25),
+						"pages": matchers.Like(map[string]interface{}{
+							"current": matchers.Like(1),
+							"total":   matchers.Like(1),
+						}),
+						"cases": matchers.EachLike(map[string]interface{}{
+							"id":  matchers.Like(58),
+							"uId": matchers.Term("7000-2830-9492", `\d{4}-\d{4}-\d{4}`),
+							"donor": matchers.Like(map[string]interface{}{
+								"id":        matchers.Like(17),
+								"uId":       matchers.Term("7000-5382-4438", `\d{4}-\d{4}-\d{4}`),
+								"firstname": matchers.Like("Wilma"),
+								"surname":   matchers.Like("Ruthman"),
+							}),
+							"caseSubtype": matchers.Term("hw", "hw|pfa"),
+							"receiptDate": matchers.Term("14/05/2021", `\d{1,2}/\d{1,2}/\d{4}`),
+							"status":      matchers.Like("Pending"),
+						}, 1),
+					}),
+				})
+
+			assert.Nil(t, pact.ExecuteTest(t, func(config consumer.MockServerConfig) error {
+				client, _ := NewClient(http.DefaultClient, fmt.Sprintf("http://127.0.0.1:%d", config.Port))
+
+				criteria := tc.criteria.Filter("status", "Pending").Sort("receiptDate", Ascending).Page(1)
+				cases, _, err := client.CasesByAssignee(Context{Context: context.Background()}, 47, criteria)
+				assert.Nil(t, err)
+				assert.Equal(t, "Wilma Ruthman", cases[0].Donor.DisplayName())
+				return nil
+			}))
+		})
+	}
+}
+
 func TestHasWorkableCase(t *testing.T) {
 	pact, _ := newPact()
 
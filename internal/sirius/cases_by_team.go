@@ -31,7 +31,7 @@ func (c *Client) CasesByTeam(ctx Context, id int, criteria Criteria) (*CasesByTe
 		return nil, err
 	}
 
-	resp, err := c.http.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, err
 	}
@@ -11,15 +11,15 @@ type apiPages struct {
 	Total   int `json:"total"`
 }
 
-func (c *Client) CasesWithOpenTasksByAssignee(ctx Context, id, page int) ([]Case, *Pagination, error) {
-	url := fmt.Sprintf("/lpa-api/v1/assignees/%d/cases-with-open-tasks?page=%d", id, page)
+func (c *Client) CasesWithOpenTasksByAssignee(ctx Context, id int, criteria Criteria) ([]Case, *Pagination, error) {
+	url := fmt.Sprintf("/lpa-api/v1/assignees/%d/cases-with-open-tasks?%s", id, criteria.String())
 
 	req, err := c.newRequest(ctx, http.MethodGet, url, nil)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	resp, err := c.http.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, nil, err
 	}
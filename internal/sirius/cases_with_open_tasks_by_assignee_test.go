@@ -94,7 +94,7 @@ func TestCasesWithOpenTasksByAssignee(t *testing.T) {
 			assert.Nil(t, pact.ExecuteTest(t, func(config consumer.MockServerConfig) error {
 				client, _ := NewClient(http.DefaultClient, fmt.Sprintf("http://127.0.0.1:%d", config.Port))
 
-				cases, pagination, err := client.CasesWithOpenTasksByAssignee(Context{Context: context.Background()}, 47, 1)
+				cases, pagination, err := client.CasesWithOpenTasksByAssignee(Context{Context: context.Background()}, 47, Criteria{}.Page(1))
 				assert.Equal(t, tc.expectedCases, cases)
 				assert.Equal(t, tc.expectedPagination, pagination)
 				assert.Equal(t, tc.expectedError, err)
@@ -110,7 +110,7 @@ func TestCasesWithOpenTasksByAssigneeStatusError(t *testing.T) {
 
 	client, _ := NewClient(http.DefaultClient, s.URL)
 
-	_, _, err := client.CasesWithOpenTasksByAssignee(Context{Context: context.Background()}, 47, 2)
+	_, _, err := client.CasesWithOpenTasksByAssignee(Context{Context: context.Background()}, 47, Criteria{}.Page(2))
 	assert.Equal(t, &StatusError{
 		Code:   http.StatusTeapot,
 		URL:    s.URL + "/lpa-api/v1/assignees/47/cases-with-open-tasks?page=2",
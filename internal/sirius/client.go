@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"time"
 )
 
 const ErrUnauthorized ClientError = "unauthorized"
@@ -16,20 +17,22 @@ func (e ClientError) Error() string {
 }
 
 type StatusError struct {
-	Code   int    `json:"code"`
-	URL    string `json:"url"`
-	Method string `json:"method"`
-	Body   string `json:"body"`
+	Code    int      `json:"code"`
+	URL     string   `json:"url"`
+	Method  string   `json:"method"`
+	Body    string   `json:"body"`
+	Problem *Problem `json:"problem,omitempty"`
 }
 
 func newStatusError(resp *http.Response) *StatusError {
 	data, _ := io.ReadAll(resp.Body)
 
 	return &StatusError{
-		Code:   resp.StatusCode,
-		URL:    resp.Request.URL.String(),
-		Method: resp.Request.Method,
-		Body:   string(data),
+		Code:    resp.StatusCode,
+		URL:     resp.Request.URL.String(),
+		Method:  resp.Request.Method,
+		Body:    string(data),
+		Problem: parseProblem(data),
 	}
 }
 
@@ -38,9 +41,29 @@ func (e *StatusError) Error() string {
 }
 
 func (e *StatusError) Title() string {
+	if e.Problem != nil && e.Problem.Title != "" {
+		return e.Problem.Title
+	}
+
 	return "unexpected response from Sirius"
 }
 
+func (e *StatusError) Detail() string {
+	if e.Problem == nil {
+		return ""
+	}
+
+	return e.Problem.Detail
+}
+
+func (e *StatusError) FieldErrors() []FieldError {
+	if e.Problem == nil {
+		return nil
+	}
+
+	return e.Problem.FieldErrors()
+}
+
 func (e *StatusError) Data() interface{} {
 	return e
 }
@@ -49,18 +72,44 @@ type Context struct {
 	Context   context.Context
 	Cookies   []*http.Cookie
 	XSRFToken string
+
+	// Token authenticates requests made by the dashboard itself, rather than
+	// on behalf of a user's session.
+	Token string
 }
 
 func NewClient(httpClient *http.Client, baseURL string) (*Client, error) {
 	return &Client{
-		http:    httpClient,
-		baseURL: baseURL,
+		http:     httpClient,
+		baseURL:  baseURL,
+		retry:    defaultRetryPolicy,
+		breaker:  newCircuitBreaker(5, 30*time.Second),
+		pageSize: defaultPageSize,
+
+		assignChunkSize: defaultAssignChunkSize,
 	}, nil
 }
 
 type Client struct {
-	http    *http.Client
-	baseURL string
+	http     *http.Client
+	baseURL  string
+	retry    retryPolicy
+	breaker  *circuitBreaker
+	pageSize int
+
+	assignChunkSize int
+}
+
+func (c *Client) do(req *http.Request) (*http.Response, error) {
+	probe, err := c.breaker.allow()
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.doWithRetry(req)
+	c.breaker.record(probe, resp, err)
+
+	return resp, err
 }
 
 func (c *Client) newRequest(ctx Context, method, path string, body io.Reader) (*http.Request, error) {
@@ -75,6 +124,9 @@ func (c *Client) newRequest(ctx Context, method, path string, body io.Reader) (*
 
 	req.Header.Add("OPG-Bypass-Membrane", "1")
 	req.Header.Add("X-XSRF-TOKEN", ctx.XSRFToken)
+	if ctx.Token != "" {
+		req.Header.Add("Authorization", "Bearer "+ctx.Token)
+	}
 	if body != nil {
 		req.Header.Add("Content-Type", "application/json")
 	}
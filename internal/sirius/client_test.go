@@ -1,6 +1,7 @@
 package sirius
 
 import (
+	"context"
 	"io"
 	"net/http"
 	"net/http/httptest"
@@ -29,6 +30,16 @@ func teapotServer() *httptest.Server {
 	)
 }
 
+func TestNewRequestToken(t *testing.T) {
+	client, _ := NewClient(http.DefaultClient, "http://localhost")
+
+	req, _ := client.newRequest(Context{Context: context.Background()}, http.MethodGet, "/", nil)
+	assert.Equal(t, "", req.Header.Get("Authorization"))
+
+	req, _ = client.newRequest(Context{Context: context.Background(), Token: "abc"}, http.MethodGet, "/", nil)
+	assert.Equal(t, "Bearer abc", req.Header.Get("Authorization"))
+}
+
 func TestClientError(t *testing.T) {
 	assert.Equal(t, "message", ClientError("message").Error())
 }
@@ -49,3 +60,56 @@ func TestStatusError(t *testing.T) {
 	assert.Equal(t, err, err.Data())
 	assert.Equal(t, "a body", err.Body)
 }
+
+func TestStatusErrorProblem(t *testing.T) {
+	req, _ := http.NewRequest(http.MethodPut, "/some/url", nil)
+
+	resp := &http.Response{
+		StatusCode: http.StatusBadRequest,
+		Request:    req,
+		Body: io.NopCloser(strings.NewReader(`{
+			"type": "http://www.w3.org/Protocols/rfc2616/rfc2616-sec10.html",
+			"title": "Bad Request",
+			"status": 400,
+			"detail": "Payload failed validation",
+			"validation_errors": {
+				"worked": {"notBoolean": "Value must be a boolean"},
+				"assigneeId": {"notFound": "User not found", "isEmpty": "Value is required"}
+			}
+		}`)),
+	}
+
+	err := newStatusError(resp)
+
+	assert.Equal(t, "PUT /some/url returned 400", err.Error())
+	assert.Equal(t, "Bad Request", err.Title())
+	assert.Equal(t, "Payload failed validation", err.Detail())
+	assert.Equal(t, []FieldError{
+		{Field: "assigneeId", Message: "User not found", Rule: "notFound"},
+		{Field: "assigneeId", Message: "Value is required", Rule: "isEmpty"},
+		{Field: "worked", Message: "Value must be a boolean", Rule: "notBoolean"},
+	}, err.FieldErrors())
+}
+
+func TestStatusErrorWithoutProblem(t *testing.T) {
+	for name, body := range map[string]string{
+		"empty":     "",
+		"html":      "<html>Bad Gateway</html>",
+		"unrelated": `{"cases":[]}`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			req, _ := http.NewRequest(http.MethodGet, "/some/url", nil)
+
+			err := newStatusError(&http.Response{
+				StatusCode: http.StatusBadGateway,
+				Request:    req,
+				Body:       io.NopCloser(strings.NewReader(body)),
+			})
+
+			assert.Nil(t, err.Problem)
+			assert.Equal(t, "unexpected response from Sirius", err.Title())
+			assert.Equal(t, "", err.Detail())
+			assert.Nil(t, err.FieldErrors())
+		})
+	}
+}
@@ -49,6 +49,12 @@ func (c Criteria) Limit(id int) Criteria {
 	return c
 }
 
+// Unsorted returns the criteria without any sort, keeping its filters.
+func (c Criteria) Unsorted() Criteria {
+	c.sort = nil
+	return c
+}
+
 func (c Criteria) Filter(field string, value string) Criteria {
 	c.filter = append(c.filter, filter{
 		field: field,
@@ -94,3 +100,35 @@ func (c *Criteria) String() string {
 
 	return params.Encode()
 }
+
+// FilterValues returns the values the criteria filters the given field on, in
+// the order they were added.
+func (c Criteria) FilterValues(field string) []string {
+	var values []string
+	for _, f := range c.filter {
+		if f.field == field {
+			values = append(values, f.value)
+		}
+	}
+
+	return values
+}
+
+func (c Criteria) IsFiltered() bool {
+	return len(c.filter) > 0
+}
+
+// IsSorted reports whether a sort has been set.
+func (c Criteria) IsSorted() bool {
+	return len(c.sort) > 0
+}
+
+// FirstSort returns the field and order the criteria sorts by first, or empty
+// strings when it is not sorted.
+func (c Criteria) FirstSort() (string, sortOrder) {
+	if len(c.sort) == 0 {
+		return "", ""
+	}
+
+	return c.sort[0].field, c.sort[0].order
+}
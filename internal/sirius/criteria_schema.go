@@ -0,0 +1,161 @@
+package sirius
+
+import (
+	"fmt"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type FilterType int
+
+const (
+	TextFilter FilterType = iota
+	IntFilter
+	DateFilter
+	BoolFilter
+)
+
+// FilterField declares a filter that can be set from a query string. The
+// query parameter and the Sirius filter share the same name. If Values is set
+// only those values are accepted.
+type FilterField struct {
+	Name     string
+	Type     FilterType
+	Multiple bool
+	Values   []string
+}
+
+// CriteriaSchema declares which filters and sorts a page accepts, so that
+// Criteria can be read from, and written back to, a query string.
+type CriteriaSchema struct {
+	Filters []FilterField
+	Sorts   []string
+}
+
+// CriteriaError lists the query parameters that could not be turned into
+// Criteria, and why.
+type CriteriaError []FieldError
+
+func (e CriteriaError) Error() string {
+	parts := make([]string, len(e))
+	for i, fieldError := range e {
+		parts[i] = fieldError.Field + ": " + fieldError.Message
+	}
+
+	return "invalid criteria: " + strings.Join(parts, ", ")
+}
+
+// Parse reads the declared filters and sorts from form. Empty values are
+// treated as not set, other parameters are ignored.
+func (s CriteriaSchema) Parse(form url.Values) (Criteria, error) {
+	var c Criteria
+	var errs CriteriaError
+
+	for _, field := range s.Filters {
+		var values []string
+		for _, v := range form[field.Name] {
+			if v = strings.TrimSpace(v); v != "" {
+				values = append(values, v)
+			}
+		}
+
+		if len(values) > 1 && !field.Multiple {
+			errs = append(errs, FieldError{Field: field.Name, Message: "must only have one value"})
+			continue
+		}
+
+		for _, v := range values {
+			value, err := field.parse(v)
+			if err != nil {
+				errs = append(errs, FieldError{Field: field.Name, Message: err.Error()})
+				continue
+			}
+
+			c = c.Filter(field.Name, value)
+		}
+	}
+
+	for _, param := range form["sort"] {
+		for _, v := range strings.Split(param, ",") {
+			if v == "" {
+				continue
+			}
+
+			field, order, _ := strings.Cut(v, ":")
+			if !slices.Contains(s.Sorts, field) {
+				errs = append(errs, FieldError{Field: "sort", Message: fmt.Sprintf("cannot sort by %q", field)})
+				continue
+			}
+
+			switch sortOrder(order) {
+			case Ascending, "":
+				c = c.Sort(field, Ascending)
+			case Descending:
+				c = c.Sort(field, Descending)
+			default:
+				errs = append(errs, FieldError{Field: "sort", Message: fmt.Sprintf("unknown sort order %q", order)})
+			}
+		}
+	}
+
+	if len(errs) > 0 {
+		return Criteria{}, errs
+	}
+
+	return c, nil
+}
+
+func (f FilterField) parse(v string) (string, error) {
+	switch f.Type {
+	case IntFilter:
+		if _, err := strconv.Atoi(v); err != nil {
+			return "", fmt.Errorf("must be a whole number")
+		}
+	case DateFilter:
+		t, err := time.Parse("2006-01-02", v)
+		if err != nil {
+			return "", fmt.Errorf("must be a date")
+		}
+		v = t.Format("2006-01-02")
+	case BoolFilter:
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			return "", fmt.Errorf("must be true or false")
+		}
+		v = strconv.FormatBool(b)
+	}
+
+	if len(f.Values) > 0 && !slices.Contains(f.Values, v) {
+		return "", fmt.Errorf("must be one of %s", strings.Join(f.Values, ", "))
+	}
+
+	return v, nil
+}
+
+// Encode writes the declared filters and sorts of c back to query parameters,
+// so they can be carried through links such as pagination.
+func (s CriteriaSchema) Encode(c Criteria) url.Values {
+	form := url.Values{}
+
+	for _, f := range c.filter {
+		if slices.ContainsFunc(s.Filters, func(field FilterField) bool { return field.Name == f.field }) {
+			form.Add(f.field, f.value)
+		}
+	}
+
+	var sorts []string
+	for _, sort := range c.sort {
+		if slices.Contains(s.Sorts, sort.field) {
+			sorts = append(sorts, sort.String())
+		}
+	}
+
+	if len(sorts) > 0 {
+		form.Set("sort", strings.Join(sorts, ","))
+	}
+
+	return form
+}
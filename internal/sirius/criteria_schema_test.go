@@ -0,0 +1,107 @@
+package sirius
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var testSchema = CriteriaSchema{
+	Filters: []FilterField{
+		{Name: "allocation", Type: IntFilter, Multiple: true},
+		{Name: "status", Multiple: true, Values: []string{"pending", "pending-worked"}},
+		{Name: "date-from", Type: DateFilter},
+		{Name: "worked", Type: BoolFilter},
+		{Name: "lpa-type", Values: []string{"pfa", "hw"}},
+	},
+	Sorts: []string{"receiptDate", "workedDate"},
+}
+
+func TestCriteriaSchemaParse(t *testing.T) {
+	testCases := map[string]struct {
+		Query    string
+		Criteria Criteria
+	}{
+		"empty": {
+			Query:    "",
+			Criteria: Criteria{},
+		},
+		"ignores unknown and empty parameters": {
+			Query:    "page=2&xsrfToken=abc&date-from=&lpa-type=",
+			Criteria: Criteria{},
+		},
+		"filters in schema order": {
+			Query: "lpa-type=hw&status=pending&allocation=1&allocation=2&worked=1&date-from=2021-01-02",
+			Criteria: Criteria{}.
+				Filter("allocation", "1").
+				Filter("allocation", "2").
+				Filter("status", "pending").
+				Filter("date-from", "2021-01-02").
+				Filter("worked", "true").
+				Filter("lpa-type", "hw"),
+		},
+		"sorts": {
+			Query:    "sort=workedDate:desc,receiptDate",
+			Criteria: Criteria{}.Sort("workedDate", Descending).Sort("receiptDate", Ascending),
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			form, _ := url.ParseQuery(tc.Query)
+
+			criteria, err := testSchema.Parse(form)
+			assert.Nil(t, err)
+			assert.Equal(t, tc.Criteria, criteria)
+		})
+	}
+}
+
+func TestCriteriaSchemaParseInvalid(t *testing.T) {
+	form, _ := url.ParseQuery("allocation=1&allocation=x&status=what&date-from=2021-13-01&worked=maybe&lpa-type=hw&lpa-type=pfa&sort=donor:asc,receiptDate:up")
+
+	_, err := testSchema.Parse(form)
+	assert.Equal(t, CriteriaError{
+		{Field: "allocation", Message: "must be a whole number"},
+		{Field: "status", Message: "must be one of pending, pending-worked"},
+		{Field: "date-from", Message: "must be a date"},
+		{Field: "worked", Message: "must be true or false"},
+		{Field: "lpa-type", Message: "must only have one value"},
+		{Field: "sort", Message: `cannot sort by "donor"`},
+		{Field: "sort", Message: `unknown sort order "up"`},
+	}, err)
+}
+
+func TestCriteriaError(t *testing.T) {
+	err := CriteriaError{
+		{Field: "status", Message: "must be one of pending"},
+		{Field: "sort", Message: "cannot sort by \"donor\""},
+	}
+
+	assert.Equal(t, `invalid criteria: status: must be one of pending, sort: cannot sort by "donor"`, err.Error())
+}
+
+func TestCriteriaSchemaEncode(t *testing.T) {
+	criteria := Criteria{}.
+		Filter("status", "pending").
+		Filter("allocation", "1").
+		Filter("caseType", "lpa").
+		Sort("receiptDate", Ascending).
+		Sort("dueDate", Descending).
+		Page(3)
+
+	assert.Equal(t, url.Values{
+		"status":     {"pending"},
+		"allocation": {"1"},
+		"sort":       {"receiptDate:asc"},
+	}, testSchema.Encode(criteria))
+}
+
+func TestCriteriaSchemaRoundTrip(t *testing.T) {
+	form, _ := url.ParseQuery("allocation=1&allocation=2&date-from=2021-01-02&sort=workedDate%3Adesc&status=pending")
+
+	criteria, err := testSchema.Parse(form)
+	assert.Nil(t, err)
+	assert.Equal(t, form, testSchema.Encode(criteria))
+}
@@ -71,3 +71,33 @@ func TestCriteria(t *testing.T) {
 		})
 	}
 }
+
+func TestCriteriaFilterValues(t *testing.T) {
+	criteria := Criteria{}.Filter("status", "Pending").Filter("type", "LPA").Filter("status", "Registered")
+
+	assert.Equal(t, []string{"Pending", "Registered"}, criteria.FilterValues("status"))
+	assert.Nil(t, criteria.FilterValues("other"))
+	assert.True(t, criteria.IsFiltered())
+	assert.False(t, Criteria{}.Page(1).IsFiltered())
+}
+
+func TestCriteriaFirstSort(t *testing.T) {
+	criteria := Criteria{}.Page(1).Sort("workedDate", Descending).Sort("receiptDate", Ascending)
+
+	field, order := criteria.FirstSort()
+	assert.Equal(t, "workedDate", field)
+	assert.Equal(t, Descending, order)
+	assert.True(t, criteria.IsSorted())
+
+	field, order = Criteria{}.Page(1).FirstSort()
+	assert.Equal(t, "", field)
+	assert.Equal(t, sortOrder(""), order)
+	assert.False(t, Criteria{}.Page(1).IsSorted())
+}
+
+func TestCriteriaUnsorted(t *testing.T) {
+	criteria := Criteria{}.Page(2).Filter("status", "Pending").Sort("workedDate", Descending).Unsorted()
+
+	assert.False(t, criteria.IsSorted())
+	assert.Equal(t, "filter=status%3APending&page=2", criteria.String())
+}
@@ -6,13 +6,31 @@ import (
 	"net/http"
 )
 
+// Feedback is a message from a user about the dashboard, along with where it
+// was sent from so that it can be followed up.
+type Feedback struct {
+	Message string
+	Page    string
+	Roles   []string
+	Team    string
+	Rating  int
+}
+
 type feedbackRequest struct {
-	Message string `json:"message"`
+	Message string   `json:"message"`
+	Page    string   `json:"page,omitempty"`
+	Roles   []string `json:"roles,omitempty"`
+	Team    string   `json:"team,omitempty"`
+	Rating  int      `json:"rating,omitempty"`
 }
 
-func (c *Client) Feedback(ctx Context, message string) error {
+func (c *Client) Feedback(ctx Context, feedback Feedback) error {
 	data := feedbackRequest{
-		Message: message,
+		Message: feedback.Message,
+		Page:    feedback.Page,
+		Roles:   feedback.Roles,
+		Team:    feedback.Team,
+		Rating:  feedback.Rating,
 	}
 
 	var buf bytes.Buffer
@@ -25,7 +43,7 @@ func (c *Client) Feedback(ctx Context, message string) error {
 		return err
 	}
 
-	resp, err := c.http.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return err
 	}
@@ -36,14 +54,6 @@ func (c *Client) Feedback(ctx Context, message string) error {
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		var v struct {
-			Detail string `json:"detail"`
-		}
-
-		if err := json.NewDecoder(resp.Body).Decode(&v); err == nil {
-			return ClientError(v.Detail)
-		}
-
 		return newStatusError(resp)
 	}
 
@@ -32,6 +32,10 @@ func TestFeedback(t *testing.T) {
 						Path:   matchers.String("/lpa-api/v1/feedback/poas"),
 						Body: matchers.Like(map[string]interface{}{
 							"message": matchers.String("hey"),
+							"page":    matchers.String("/pending-cases"),
+							"roles":   matchers.EachLike(matchers.String("Manager"), 1),
+							"team":    matchers.String("Casework Team 1"),
+							"rating":  matchers.Like(4),
 						}),
 					}).
 					// NB: returns 403 as feedback is disabled in dev/testing environments
@@ -50,7 +54,13 @@ func TestFeedback(t *testing.T) {
 			assert.Nil(t, pact.ExecuteTest(t, func(config consumer.MockServerConfig) error {
 				client, _ := NewClient(http.DefaultClient, fmt.Sprintf("http://127.0.0.1:%d", config.Port))
 
-				err := client.Feedback(Context{Context: context.Background()}, "hey")
+				err := client.Feedback(Context{Context: context.Background()}, Feedback{
+					Message: "hey",
+					Page:    "/pending-cases",
+					Roles:   []string{"Manager"},
+					Team:    "Casework Team 1",
+					Rating:  4,
+				})
 
 				errStatus, ok := err.(*StatusError)
 				assert.True(t, ok)
@@ -68,7 +78,7 @@ func TestFeedbackStatusError(t *testing.T) {
 
 	client, _ := NewClient(http.DefaultClient, s.URL)
 
-	err := client.Feedback(Context{Context: context.Background()}, "hey")
+	err := client.Feedback(Context{Context: context.Background()}, Feedback{Message: "hey"})
 	assert.Equal(t, &StatusError{
 		Code:   http.StatusTeapot,
 		URL:    s.URL + "/lpa-api/v1/feedback/poas",
@@ -0,0 +1,87 @@
+package sirius
+
+import (
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewHTTPClient(t *testing.T) {
+	assert := assert.New(t)
+
+	client, err := NewHTTPClient(DefaultHTTPConfig)
+	assert.Nil(err)
+	assert.Equal(30*time.Second, client.Timeout)
+
+	transport := client.Transport.(*http.Transport)
+	assert.Equal(5*time.Second, transport.TLSHandshakeTimeout)
+	assert.Equal(20*time.Second, transport.ResponseHeaderTimeout)
+	assert.Equal(10, transport.MaxIdleConnsPerHost)
+	assert.Nil(transport.TLSClientConfig)
+}
+
+func TestNewHTTPClientCABundle(t *testing.T) {
+	assert := assert.New(t)
+
+	s := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer s.Close()
+
+	path := filepath.Join(t.TempDir(), "ca.pem")
+	bundle := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: s.Certificate().Raw})
+	assert.Nil(os.WriteFile(path, bundle, 0o600))
+
+	config := DefaultHTTPConfig
+	config.CABundlePath = path
+
+	client, err := NewHTTPClient(config)
+	assert.Nil(err)
+
+	resp, err := client.Get(s.URL)
+	assert.Nil(err)
+	assert.Equal(http.StatusOK, resp.StatusCode)
+	resp.Body.Close() //nolint:errcheck // no need to check error when closing body
+}
+
+func TestNewHTTPClientBadCABundle(t *testing.T) {
+	dir := t.TempDir()
+	empty := filepath.Join(dir, "empty.pem")
+	assert.Nil(t, os.WriteFile(empty, []byte("not a certificate"), 0o600))
+
+	for name, path := range map[string]string{
+		"missing": filepath.Join(dir, "missing.pem"),
+		"empty":   empty,
+	} {
+		t.Run(name, func(t *testing.T) {
+			config := DefaultHTTPConfig
+			config.CABundlePath = path
+
+			_, err := NewHTTPClient(config)
+			assert.NotNil(t, err)
+		})
+	}
+}
+
+func TestNewHTTPClientProxy(t *testing.T) {
+	assert := assert.New(t)
+
+	config := DefaultHTTPConfig
+	config.ProxyURL = "http://proxy.internal:3128"
+
+	client, err := NewHTTPClient(config)
+	assert.Nil(err)
+
+	req, _ := http.NewRequest(http.MethodGet, "http://sirius/lpa-api/v1/users/current", nil)
+	proxy, err := client.Transport.(*http.Transport).Proxy(req)
+	assert.Nil(err)
+	assert.Equal("http://proxy.internal:3128", proxy.String())
+
+	config.ProxyURL = "://bad"
+	_, err = NewHTTPClient(config)
+	assert.NotNil(err)
+}
@@ -7,12 +7,23 @@ import (
 )
 
 func (c *Client) MarkWorked(ctx Context, id int) error {
-	req, err := c.newRequest(ctx, http.MethodPut, fmt.Sprintf("/lpa-api/v1/lpas/%d", id), strings.NewReader(`{"worked":true}`))
+	return c.setWorked(ctx, id, true)
+}
+
+// MarkUnworked reverses MarkWorked, so that the case shows as pending again.
+func (c *Client) MarkUnworked(ctx Context, id int) error {
+	return c.setWorked(ctx, id, false)
+}
+
+func (c *Client) setWorked(ctx Context, id int, worked bool) error {
+	body := fmt.Sprintf(`{"worked":%t}`, worked)
+
+	req, err := c.newRequest(ctx, http.MethodPut, fmt.Sprintf("/lpa-api/v1/lpas/%d", id), strings.NewReader(body))
 	if err != nil {
 		return err
 	}
 
-	resp, err := c.http.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return err
 	}
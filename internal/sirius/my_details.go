@@ -51,7 +51,7 @@ func (c *Client) MyDetails(ctx Context) (MyDetails, error) {
 		return v, err
 	}
 
-	resp, err := c.http.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return v, err
 	}
@@ -0,0 +1,90 @@
+package sirius
+
+import "iter"
+
+const defaultPageSize = 100
+
+// walkPages stops after yielding the first error.
+func walkPages[T any](ctx Context, fetch func(page int) ([]T, *Pagination, error)) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var zero T
+
+		for page := 1; ; page++ {
+			if err := ctx.Context.Err(); err != nil {
+				yield(zero, err)
+				return
+			}
+
+			items, pagination, err := fetch(page)
+			if err != nil {
+				yield(zero, err)
+				return
+			}
+
+			for _, item := range items {
+				if !yield(item, nil) {
+					return
+				}
+			}
+
+			if len(items) == 0 || pagination == nil || page >= pagination.TotalPages {
+				return
+			}
+		}
+	}
+}
+
+// SetPageSize sets how many items the All methods ask for in each page, unless
+// the criteria already has a limit.
+func (c *Client) SetPageSize(size int) {
+	if size > 0 {
+		c.pageSize = size
+	}
+}
+
+func (c *Client) pageCriteria(criteria Criteria) Criteria {
+	if criteria.limit == 0 {
+		return criteria.Limit(c.pageSize)
+	}
+
+	return criteria
+}
+
+// AllCasesByAssignee walks every page of CasesByAssignee.
+func (c *Client) AllCasesByAssignee(ctx Context, id int, criteria Criteria) iter.Seq2[Case, error] {
+	criteria = c.pageCriteria(criteria)
+
+	return walkPages(ctx, func(page int) ([]Case, *Pagination, error) {
+		return c.CasesByAssignee(ctx, id, criteria.Page(page))
+	})
+}
+
+// AllCasesByTeam walks every page of CasesByTeam.
+func (c *Client) AllCasesByTeam(ctx Context, id int, criteria Criteria) iter.Seq2[Case, error] {
+	criteria = c.pageCriteria(criteria)
+
+	return walkPages(ctx, func(page int) ([]Case, *Pagination, error) {
+		result, err := c.CasesByTeam(ctx, id, criteria.Page(page))
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return result.Cases, result.Pagination, nil
+	})
+}
+
+// AllTasksByAssignee walks every page of TasksByAssignee.
+func (c *Client) AllTasksByAssignee(ctx Context, id int, criteria Criteria) iter.Seq2[Task, error] {
+	criteria = c.pageCriteria(criteria)
+
+	return walkPages(ctx, func(page int) ([]Task, *Pagination, error) {
+		return c.TasksByAssignee(ctx, id, criteria.Page(page))
+	})
+}
+
+// AllCasesWithOpenTasksByAssignee walks every page of CasesWithOpenTasksByAssignee.
+func (c *Client) AllCasesWithOpenTasksByAssignee(ctx Context, id int) iter.Seq2[Case, error] {
+	return walkPages(ctx, func(page int) ([]Case, *Pagination, error) {
+		return c.CasesWithOpenTasksByAssignee(ctx, id, Criteria{}.Page(page))
+	})
+}
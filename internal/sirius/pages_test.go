@@ -0,0 +1,192 @@
+package sirius
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// pagedServer serves totalPages pages with one item each, using key as the
+// name of the list in the response body.
+func pagedServer(key string, totalPages int, requests *[]string) *httptest.Server {
+	return httptest.NewServer(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			*requests = append(*requests, r.URL.RawQuery)
+
+			page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+			if page == 0 {
+				page = 1
+			}
+
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = fmt.Fprintf(w, `{"pages":{"current":%d,"total":%d},"total":%d,"limit":1,"%s":[{"id":%d}]}`, page, totalPages, totalPages, key, page)
+		}),
+	)
+}
+
+func TestAllCasesByAssignee(t *testing.T) {
+	assert := assert.New(t)
+
+	var requests []string
+	s := pagedServer("cases", 3, &requests)
+	defer s.Close()
+
+	client, _ := NewClient(http.DefaultClient, s.URL)
+
+	var ids []int
+	for c, err := range client.AllCasesByAssignee(Context{Context: context.Background()}, 5, Criteria{}.Filter("status", "Pending")) {
+		assert.Nil(err)
+		ids = append(ids, c.ID)
+	}
+
+	assert.Equal([]int{1, 2, 3}, ids)
+	assert.Len(requests, 3)
+	assert.Equal("filter=status%3APending%2CcaseType%3Alpa%2Cactive%3Atrue&limit=100&page=2", requests[1])
+}
+
+func TestAllCasesByAssigneePageSize(t *testing.T) {
+	assert := assert.New(t)
+
+	var requests []string
+	s := pagedServer("cases", 2, &requests)
+	defer s.Close()
+
+	client, _ := NewClient(http.DefaultClient, s.URL)
+	client.SetPageSize(50)
+	client.SetPageSize(0)
+
+	for _, err := range client.AllCasesByAssignee(Context{Context: context.Background()}, 5, Criteria{}) {
+		assert.Nil(err)
+	}
+
+	assert.Equal([]string{
+		"filter=caseType%3Alpa%2Cactive%3Atrue&limit=50&page=1",
+		"filter=caseType%3Alpa%2Cactive%3Atrue&limit=50&page=2",
+	}, requests)
+}
+
+func TestAllCasesByTeam(t *testing.T) {
+	assert := assert.New(t)
+
+	var requests []string
+	s := pagedServer("cases", 2, &requests)
+	defer s.Close()
+
+	client, _ := NewClient(http.DefaultClient, s.URL)
+
+	var ids []int
+	for c, err := range client.AllCasesByTeam(Context{Context: context.Background()}, 5, Criteria{}.Limit(25)) {
+		assert.Nil(err)
+		ids = append(ids, c.ID)
+	}
+
+	assert.Equal([]int{1, 2}, ids)
+	assert.Equal([]string{"limit=25&page=1", "limit=25&page=2"}, requests)
+}
+
+func TestAllTasksByAssignee(t *testing.T) {
+	assert := assert.New(t)
+
+	var requests []string
+	s := pagedServer("tasks", 2, &requests)
+	defer s.Close()
+
+	client, _ := NewClient(http.DefaultClient, s.URL)
+
+	var ids []int
+	for task, err := range client.AllTasksByAssignee(Context{Context: context.Background()}, 5, Criteria{}) {
+		assert.Nil(err)
+		ids = append(ids, task.ID)
+	}
+
+	assert.Equal([]int{1, 2}, ids)
+}
+
+func TestAllCasesWithOpenTasksByAssignee(t *testing.T) {
+	assert := assert.New(t)
+
+	var requests []string
+	s := pagedServer("cases", 2, &requests)
+	defer s.Close()
+
+	client, _ := NewClient(http.DefaultClient, s.URL)
+
+	var ids []int
+	for c, err := range client.AllCasesWithOpenTasksByAssignee(Context{Context: context.Background()}, 5) {
+		assert.Nil(err)
+		ids = append(ids, c.ID)
+	}
+
+	assert.Equal([]int{1, 2}, ids)
+	assert.Equal([]string{"page=1", "page=2"}, requests)
+}
+
+func TestAllCasesByAssigneeStopsEarly(t *testing.T) {
+	assert := assert.New(t)
+
+	var requests []string
+	s := pagedServer("cases", 3, &requests)
+	defer s.Close()
+
+	client, _ := NewClient(http.DefaultClient, s.URL)
+
+	for range client.AllCasesByAssignee(Context{Context: context.Background()}, 5, Criteria{}) {
+		break
+	}
+
+	assert.Len(requests, 1)
+}
+
+func TestAllCasesByAssigneeStatusError(t *testing.T) {
+	assert := assert.New(t)
+
+	s := teapotServer()
+	defer s.Close()
+
+	client, _ := NewClient(http.DefaultClient, s.URL)
+
+	count := 0
+	for _, err := range client.AllCasesByAssignee(Context{Context: context.Background()}, 5, Criteria{}) {
+		count++
+		assert.IsType(&StatusError{}, err)
+	}
+
+	assert.Equal(1, count)
+}
+
+func TestWalkPagesCancelled(t *testing.T) {
+	assert := assert.New(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	fetches := 0
+	seq := walkPages(Context{Context: ctx}, func(page int) ([]int, *Pagination, error) {
+		fetches++
+		return []int{page}, &Pagination{TotalPages: 5}, nil
+	})
+
+	var items []int
+	var lastErr error
+	for item, err := range seq {
+		if err != nil {
+			lastErr = err
+			continue
+		}
+
+		items = append(items, item)
+		if item == 2 {
+			cancel()
+		}
+	}
+
+	assert.Equal([]int{1, 2}, items)
+	assert.Equal(2, fetches)
+	assert.True(errors.Is(lastErr, context.Canceled))
+}
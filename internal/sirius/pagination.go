@@ -1,8 +1,8 @@
 package sirius
 
 type Pagination struct {
-	TotalItems  int
-	CurrentPage int
-	TotalPages  int
-	PageSize    int
+	TotalItems  int `json:"totalItems"`
+	CurrentPage int `json:"currentPage"`
+	TotalPages  int `json:"totalPages"`
+	PageSize    int `json:"pageSize"`
 }
@@ -0,0 +1,57 @@
+package sirius
+
+import (
+	"encoding/json"
+	"sort"
+)
+
+// Problem is the "problem details" body Sirius sends with an error response,
+// including any validation errors keyed by field and then by failed rule.
+type Problem struct {
+	Type             string                       `json:"type"`
+	Title            string                       `json:"title"`
+	Status           int                          `json:"status"`
+	Detail           string                       `json:"detail"`
+	ValidationErrors map[string]map[string]string `json:"validation_errors"`
+}
+
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+
+	// Rule is the validation rule that failed, when the error came from Sirius.
+	Rule string `json:"-"`
+}
+
+func parseProblem(data []byte) *Problem {
+	var v Problem
+	if err := json.Unmarshal(data, &v); err != nil {
+		return nil
+	}
+
+	if v.Title == "" && v.Detail == "" && len(v.ValidationErrors) == 0 {
+		return nil
+	}
+
+	return &v
+}
+
+func (p *Problem) FieldErrors() []FieldError {
+	var errors []FieldError
+
+	for field, rules := range p.ValidationErrors {
+		for rule, message := range rules {
+			errors = append(errors, FieldError{Field: field, Message: message, Rule: rule})
+		}
+	}
+
+	sort.Slice(errors, func(i, j int) bool {
+		if errors[i].Field == errors[j].Field {
+			return errors[i].Message < errors[j].Message
+		}
+
+		return errors[i].Field < errors[j].Field
+	})
+
+	return errors
+}
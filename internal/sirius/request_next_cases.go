@@ -10,7 +10,7 @@ func (c *Client) RequestNextCases(ctx Context) error {
 		return err
 	}
 
-	resp, err := c.http.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,95 @@
+package sirius
+
+import (
+	"errors"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"syscall"
+	"time"
+)
+
+type retryPolicy struct {
+	maxAttempts int
+	baseDelay   time.Duration
+	maxDelay    time.Duration
+}
+
+var defaultRetryPolicy = retryPolicy{
+	maxAttempts: 3,
+	baseDelay:   100 * time.Millisecond,
+	maxDelay:    2 * time.Second,
+}
+
+// backoff uses "full jitter" so that concurrent requests do not retry together.
+func (p retryPolicy) backoff(attempt int) time.Duration {
+	ceiling := p.baseDelay << (attempt - 1)
+	if ceiling <= 0 || ceiling > p.maxDelay {
+		ceiling = p.maxDelay
+	}
+
+	if ceiling <= 0 {
+		return 0
+	}
+
+	return rand.N(ceiling)
+}
+
+func isIdempotent(method string) bool {
+	return method == http.MethodGet || method == http.MethodHead
+}
+
+func isRetryableStatus(code int) bool {
+	return code == http.StatusBadGateway ||
+		code == http.StatusServiceUnavailable ||
+		code == http.StatusGatewayTimeout
+}
+
+func isRetryableError(err error) bool {
+	return errors.Is(err, syscall.ECONNRESET)
+}
+
+// doWithRetry only retries idempotent requests, as repeating others could
+// apply a change twice. It stops early if the context would expire before the
+// next attempt.
+func (c *Client) doWithRetry(req *http.Request) (*http.Response, error) {
+	if !isIdempotent(req.Method) || req.Body != nil {
+		return c.http.Do(req)
+	}
+
+	ctx := req.Context()
+
+	for attempt := 1; ; attempt++ {
+		resp, err := c.http.Do(req)
+
+		if attempt >= c.retry.maxAttempts {
+			return resp, err
+		}
+
+		if err != nil && !isRetryableError(err) {
+			return resp, err
+		}
+
+		if err == nil && !isRetryableStatus(resp.StatusCode) {
+			return resp, err
+		}
+
+		delay := c.retry.backoff(attempt)
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < delay {
+			return resp, err
+		}
+
+		if resp != nil {
+			_, _ = io.Copy(io.Discard, resp.Body)
+			resp.Body.Close() //nolint:errcheck // no need to check error when closing body
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
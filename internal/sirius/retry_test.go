@@ -0,0 +1,117 @@
+package sirius
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func flakyServer(failures int, code int) (*httptest.Server, *int32) {
+	var hits int32
+
+	s := httptest.NewServer(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if int(atomic.AddInt32(&hits, 1)) <= failures {
+				w.WriteHeader(code)
+				return
+			}
+
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"id":47}`))
+		}),
+	)
+
+	return s, &hits
+}
+
+func newRetryingClient(url string) *Client {
+	client, _ := NewClient(http.DefaultClient, url)
+	client.retry = retryPolicy{maxAttempts: 3, baseDelay: time.Millisecond, maxDelay: 5 * time.Millisecond}
+
+	return client
+}
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	policy := retryPolicy{maxAttempts: 5, baseDelay: 10 * time.Millisecond, maxDelay: 25 * time.Millisecond}
+
+	for attempt, ceiling := range map[int]time.Duration{1: 10 * time.Millisecond, 2: 20 * time.Millisecond, 3: 25 * time.Millisecond, 10: 25 * time.Millisecond} {
+		for range 20 {
+			delay := policy.backoff(attempt)
+			assert.GreaterOrEqual(t, delay, time.Duration(0))
+			assert.Less(t, delay, ceiling)
+		}
+	}
+
+	assert.Equal(t, time.Duration(0), retryPolicy{}.backoff(1))
+}
+
+func TestRetryIdempotentRequest(t *testing.T) {
+	for _, code := range []int{http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout} {
+		t.Run(http.StatusText(code), func(t *testing.T) {
+			s, hits := flakyServer(2, code)
+			defer s.Close()
+
+			myDetails, err := newRetryingClient(s.URL).MyDetails(Context{Context: context.Background()})
+			assert.Nil(t, err)
+			assert.Equal(t, 47, myDetails.ID)
+			assert.Equal(t, int32(3), *hits)
+		})
+	}
+}
+
+func TestRetryGivesUpAfterMaxAttempts(t *testing.T) {
+	s, hits := flakyServer(5, http.StatusServiceUnavailable)
+	defer s.Close()
+
+	_, err := newRetryingClient(s.URL).MyDetails(Context{Context: context.Background()})
+	assert.Equal(t, &StatusError{
+		Code:   http.StatusServiceUnavailable,
+		URL:    s.URL + "/lpa-api/v1/users/current",
+		Method: http.MethodGet,
+	}, err)
+	assert.Equal(t, int32(3), *hits)
+}
+
+func TestRetryDoesNotRetryOtherStatuses(t *testing.T) {
+	s, hits := flakyServer(1, http.StatusInternalServerError)
+	defer s.Close()
+
+	_, err := newRetryingClient(s.URL).MyDetails(Context{Context: context.Background()})
+	assert.NotNil(t, err)
+	assert.Equal(t, int32(1), *hits)
+}
+
+func TestRetryDoesNotRetryNonIdempotentRequests(t *testing.T) {
+	s, hits := flakyServer(5, http.StatusServiceUnavailable)
+	defer s.Close()
+
+	client := newRetryingClient(s.URL)
+	ctx := Context{Context: context.Background()}
+
+	assert.NotNil(t, client.MarkWorked(ctx, 1))
+	results, _ := client.Assign(ctx, []int{1}, 2)
+	assert.NotNil(t, results[0].Err)
+	assert.NotNil(t, client.RequestNextCases(ctx))
+	assert.Equal(t, int32(3), *hits)
+}
+
+func TestRetryRespectsContextBudget(t *testing.T) {
+	s, hits := flakyServer(5, http.StatusServiceUnavailable)
+	defer s.Close()
+
+	client := newRetryingClient(s.URL)
+	client.retry.baseDelay = time.Minute
+	client.retry.maxDelay = time.Minute
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err := client.MyDetails(Context{Context: ctx})
+	assert.NotNil(t, err)
+	assert.Equal(t, int32(1), *hits)
+}
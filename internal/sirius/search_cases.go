@@ -0,0 +1,37 @@
+package sirius
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+)
+
+// SearchCases finds LPA cases whose UID or donor name matches the term.
+func (c *Client) SearchCases(ctx Context, term string) ([]Case, error) {
+	query := url.Values{"query": {term}, "caseType": {"lpa"}}
+
+	req, err := c.newRequest(ctx, http.MethodGet, "/lpa-api/v1/search/cases?"+query.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close() //nolint:errcheck // no need to check error when closing body
+
+	if resp.StatusCode == http.StatusUnauthorized {
+		return nil, ErrUnauthorized
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, newStatusError(resp)
+	}
+
+	var v struct {
+		Cases []Case `json:"cases"`
+	}
+	err = json.NewDecoder(resp.Body).Decode(&v)
+	return v.Cases, err
+}
@@ -0,0 +1,114 @@
+package sirius
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/pact-foundation/pact-go/v2/consumer"
+	"github.com/pact-foundation/pact-go/v2/matchers"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSearchCases(t *testing.T) {
+	pact, err := newPact()
+	assert.NoError(t, err)
+
+	testCases := []struct {
+		name          string
+		setup         func()
+		term          string
+		expectedCases []Case
+		expectedError error
+	}{
+		{
+			name: "OK",
+			setup: func() {
+				pact.
+					AddInteraction().
+					Given("A donor called Adrian Kurkjian has an LPA").
+					UponReceiving("A search for cases by donor name").
+					WithCompleteRequest(consumer.Request{
+						Method: http.MethodGet,
+						Path:   matchers.String("/lpa-api/v1/search/cases"),
+						Query: matchers.MapMatcher{
+							"query":    matchers.String("kurkjian"),
+							"caseType": matchers.String("lpa"),
+						},
+					}).
+					WithCompleteResponse(consumer.Response{
+						Status:  http.StatusOK,
+						Headers: matchers.MapMatcher{"Content-Type": matchers.String("application/json")},
+						Body: matchers.Like(map[string]interface{}{
+							"cases": matchers.EachLike(map[string]interface{}{
+								"id":  matchers.Like(36),
+								"uId": matchers.Term("7000-8548-8461", `\d{4}-\d{4}-\d{4}`),
+								"donor": matchers.Like(map[string]interface{}{
+									"id":        matchers.Like(23),
+									"uId":       matchers.Term("7000-5382-4438", `\d{4}-\d{4}-\d{4}`),
+									"firstname": matchers.Like("Adrian"),
+									"surname":   matchers.Like("Kurkjian"),
+								}),
+								"caseSubtype": matchers.Term("pfa", "hw|pfa"),
+								"receiptDate": matchers.Term("10/03/2021", `^\d{1,2}/\d{1,2}/\d{4}$`),
+								"status":      matchers.Like("Pending"),
+								"assignee": matchers.Like(map[string]interface{}{
+									"id":          matchers.Like(47),
+									"displayName": matchers.Like("John Smith"),
+								}),
+							}, 1),
+						}),
+					})
+			},
+			term: "kurkjian",
+			expectedCases: []Case{{
+				ID:  36,
+				Uid: "7000-8548-8461",
+				Donor: Donor{
+					ID:        23,
+					Uid:       "7000-5382-4438",
+					Firstname: "Adrian",
+					Surname:   "Kurkjian",
+				},
+				SubType:     "pfa",
+				ReceiptDate: SiriusDate{time.Date(2021, 3, 10, 0, 0, 0, 0, time.UTC)},
+				Status:      "Pending",
+				Assignee: Assignee{
+					ID:          47,
+					DisplayName: "John Smith",
+				},
+			}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.setup()
+
+			assert.Nil(t, pact.ExecuteTest(t, func(config consumer.MockServerConfig) error {
+				client, _ := NewClient(http.DefaultClient, fmt.Sprintf("http://127.0.0.1:%d", config.Port))
+
+				cases, err := client.SearchCases(Context{Context: context.Background()}, tc.term)
+				assert.Equal(t, tc.expectedCases, cases)
+				assert.Equal(t, tc.expectedError, err)
+				return nil
+			}))
+		})
+	}
+}
+
+func TestSearchCasesStatusError(t *testing.T) {
+	s := teapotServer()
+	defer s.Close()
+
+	client, _ := NewClient(http.DefaultClient, s.URL)
+
+	_, err := client.SearchCases(Context{Context: context.Background()}, "7000-8548-8461")
+	assert.Equal(t, &StatusError{
+		Code:   http.StatusTeapot,
+		URL:    s.URL + "/lpa-api/v1/search/cases?caseType=lpa&query=7000-8548-8461",
+		Method: http.MethodGet,
+	}, err)
+}
@@ -0,0 +1,33 @@
+package sirius
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+)
+
+// SearchUsers finds users whose name matches the term.
+func (c *Client) SearchUsers(ctx Context, term string) ([]Assignee, error) {
+	req, err := c.newRequest(ctx, http.MethodGet, "/lpa-api/v1/search/users?"+url.Values{"query": {term}}.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close() //nolint:errcheck // no need to check error when closing body
+
+	if resp.StatusCode == http.StatusUnauthorized {
+		return nil, ErrUnauthorized
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, newStatusError(resp)
+	}
+
+	var v []Assignee
+	err = json.NewDecoder(resp.Body).Decode(&v)
+	return v, err
+}
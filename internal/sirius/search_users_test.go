@@ -0,0 +1,87 @@
+package sirius
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/pact-foundation/pact-go/v2/consumer"
+	"github.com/pact-foundation/pact-go/v2/matchers"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSearchUsers(t *testing.T) {
+	pact, err := newPact()
+	assert.NoError(t, err)
+
+	testCases := []struct {
+		name          string
+		setup         func()
+		expectedUsers []Assignee
+		expectedError error
+	}{
+		{
+			name: "OK",
+			setup: func() {
+				pact.
+					AddInteraction().
+					Given("A user called John exists").
+					UponReceiving("A search for users called John").
+					WithCompleteRequest(consumer.Request{
+						Method: http.MethodGet,
+						Path:   matchers.String("/lpa-api/v1/search/users"),
+						Query: matchers.MapMatcher{
+							"query": matchers.String("john"),
+						},
+					}).
+					WithCompleteResponse(consumer.Response{
+						Status:  http.StatusOK,
+						Headers: matchers.MapMatcher{"Content-Type": matchers.String("application/json")},
+						Body: matchers.EachLike(map[string]interface{}{
+							"id":          matchers.Like(47),
+							"displayName": matchers.Like("John Smith"),
+							"teams": matchers.EachLike(map[string]interface{}{
+								"id":          matchers.Like(66),
+								"displayName": matchers.Like("Cool Team"),
+							}, 1),
+						}, 1),
+					})
+			},
+			expectedUsers: []Assignee{{
+				ID:          47,
+				DisplayName: "John Smith",
+				Teams:       []Team{{ID: 66, DisplayName: "Cool Team"}},
+			}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.setup()
+
+			assert.Nil(t, pact.ExecuteTest(t, func(config consumer.MockServerConfig) error {
+				client, _ := NewClient(http.DefaultClient, fmt.Sprintf("http://127.0.0.1:%d", config.Port))
+
+				users, err := client.SearchUsers(Context{Context: context.Background()}, "john")
+				assert.Equal(t, tc.expectedUsers, users)
+				assert.Equal(t, tc.expectedError, err)
+				return nil
+			}))
+		})
+	}
+}
+
+func TestSearchUsersStatusError(t *testing.T) {
+	s := teapotServer()
+	defer s.Close()
+
+	client, _ := NewClient(http.DefaultClient, s.URL)
+
+	_, err := client.SearchUsers(Context{Context: context.Background()}, "john smith")
+	assert.Equal(t, &StatusError{
+		Code:   http.StatusTeapot,
+		URL:    s.URL + "/lpa-api/v1/search/users?query=john+smith",
+		Method: http.MethodGet,
+	}, err)
+}
@@ -1,24 +1,58 @@
 package sirius
 
 import (
+	"encoding/json"
 	"strings"
 	"time"
 )
 
+// siriusDateFormats are the layouts Sirius uses for dates, tried in order.
+var siriusDateFormats = []string{
+	"02/01/2006",
+	"2006-01-02",
+	time.RFC3339,
+	"2006-01-02T15:04:05",
+	"02/01/2006 15:04:05",
+}
+
+// SiriusDate is a date read from Sirius. A zero value means that Sirius did
+// not give a date.
 type SiriusDate struct {
 	time.Time
 }
 
+// UnmarshalJSON accepts any of the formats Sirius sends. A null, empty or
+// unrecognised value gives a zero date rather than an error, so that one bad
+// date does not stop the rest of a response from being read.
 func (sd *SiriusDate) UnmarshalJSON(input []byte) error {
-	strInput := string(input)
-	strInput = strings.Trim(strInput, `"`)
-	strInput = strings.ReplaceAll(strInput, "\\/", "/")
+	sd.Time = time.Time{}
+
+	var strInput string
+	if err := json.Unmarshal(input, &strInput); err != nil {
+		return nil
+	}
+
+	strInput = strings.TrimSpace(strInput)
+	if strInput == "" {
+		return nil
+	}
 
-	newTime, err := time.Parse("02/01/2006", strInput)
-	if err != nil {
-		return err
+	for _, format := range siriusDateFormats {
+		if newTime, err := time.Parse(format, strInput); err == nil {
+			sd.Time = newTime
+			return nil
+		}
 	}
 
-	sd.Time = newTime
 	return nil
 }
+
+// MarshalJSON writes the date in ISO-8601 format, or null if there is no
+// date.
+func (sd SiriusDate) MarshalJSON() ([]byte, error) {
+	if sd.IsZero() {
+		return []byte("null"), nil
+	}
+
+	return json.Marshal(sd.Format("2006-01-02"))
+}
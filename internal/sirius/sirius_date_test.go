@@ -0,0 +1,63 @@
+package sirius
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSiriusDateUnmarshalJSON(t *testing.T) {
+	testCases := map[string]struct {
+		Input    string
+		Expected time.Time
+	}{
+		"sirius":           {Input: `"12/05/2021"`, Expected: time.Date(2021, 5, 12, 0, 0, 0, 0, time.UTC)},
+		"escaped":          {Input: `"12\/05\/2021"`, Expected: time.Date(2021, 5, 12, 0, 0, 0, 0, time.UTC)},
+		"iso date":         {Input: `"2021-05-12"`, Expected: time.Date(2021, 5, 12, 0, 0, 0, 0, time.UTC)},
+		"iso timestamp":    {Input: `"2021-05-12T10:11:12+01:00"`, Expected: time.Date(2021, 5, 12, 10, 11, 12, 0, time.FixedZone("", 3600))},
+		"local timestamp":  {Input: `"2021-05-12T10:11:12"`, Expected: time.Date(2021, 5, 12, 10, 11, 12, 0, time.UTC)},
+		"sirius timestamp": {Input: `"12/05/2021 10:11:12"`, Expected: time.Date(2021, 5, 12, 10, 11, 12, 0, time.UTC)},
+		"null":             {Input: `null`},
+		"empty":            {Input: `""`},
+		"unrecognised":     {Input: `"next tuesday"`},
+		"not a string":     {Input: `20210512`},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var v SiriusDate
+			err := json.Unmarshal([]byte(tc.Input), &v)
+
+			assert.Nil(t, err)
+			assert.True(t, tc.Expected.Equal(v.Time), "expected %s, got %s", tc.Expected, v.Time)
+			assert.Equal(t, tc.Expected.IsZero(), v.IsZero())
+		})
+	}
+}
+
+func TestSiriusDateInList(t *testing.T) {
+	var v struct {
+		Cases []Case `json:"cases"`
+	}
+
+	err := json.Unmarshal([]byte(`{"cases":[{"id":1,"receiptDate":null},{"id":2},{"id":3,"receiptDate":"12/05/2021"}]}`), &v)
+	assert.Nil(t, err)
+	assert.Len(t, v.Cases, 3)
+	assert.True(t, v.Cases[0].ReceiptDate.IsZero())
+	assert.True(t, v.Cases[1].ReceiptDate.IsZero())
+	assert.Equal(t, SiriusDate{time.Date(2021, 5, 12, 0, 0, 0, 0, time.UTC)}, v.Cases[2].ReceiptDate)
+}
+
+func TestSiriusDateMarshalJSON(t *testing.T) {
+	data, err := json.Marshal(struct {
+		Date  SiriusDate `json:"date"`
+		Empty SiriusDate `json:"empty"`
+	}{
+		Date: SiriusDate{time.Date(2021, 5, 12, 0, 0, 0, 0, time.UTC)},
+	})
+
+	assert.Nil(t, err)
+	assert.JSONEq(t, `{"date":"2021-05-12","empty":null}`, string(data))
+}
@@ -0,0 +1,46 @@
+package sirius
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+const (
+	TaskNotStarted = "Not started"
+	TaskInProgress = "In progress"
+)
+
+// StartTask moves the task to in progress.
+func (c *Client) StartTask(ctx Context, id int) error {
+	return c.updateTask(ctx, fmt.Sprintf("/lpa-api/v1/tasks/%d", id), strings.NewReader(`{"status":"In progress"}`))
+}
+
+// CompleteTask marks the task as completed.
+func (c *Client) CompleteTask(ctx Context, id int) error {
+	return c.updateTask(ctx, fmt.Sprintf("/lpa-api/v1/tasks/%d/mark-as-completed", id), nil)
+}
+
+func (c *Client) updateTask(ctx Context, url string, body io.Reader) error {
+	req, err := c.newRequest(ctx, http.MethodPut, url, body)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close() //nolint:errcheck // no need to check error when closing body
+
+	if resp.StatusCode == http.StatusUnauthorized {
+		return ErrUnauthorized
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return newStatusError(resp)
+	}
+
+	return nil
+}
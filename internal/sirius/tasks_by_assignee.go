@@ -14,10 +14,21 @@ type Task struct {
 	CaseItems []TaskCaseItem `json:"caseItems"`
 }
 
+// Case returns the first case the task is linked to, or an empty case if it is
+// not linked to any. Use CaseItems to see every linked case.
 func (t Task) Case() TaskCaseItem {
+	if len(t.CaseItems) == 0 {
+		return TaskCaseItem{}
+	}
+
 	return t.CaseItems[0]
 }
 
+// HasCase reports whether the task is linked to at least one case.
+func (t Task) HasCase() bool {
+	return len(t.CaseItems) > 0
+}
+
 type TaskCaseItem struct {
 	ID      int    `json:"id"`
 	Uid     string `json:"uid"`
@@ -33,7 +44,7 @@ func (c *Client) TasksByAssignee(ctx Context, id int, criteria Criteria) ([]Task
 		return nil, nil, err
 	}
 
-	resp, err := c.http.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
 
@@ -127,3 +128,41 @@ func TestTasksByAssigneeStatusError(t *testing.T) {
 		Method: http.MethodGet,
 	}, err)
 }
+
+func TestTasksByAssigneeCaseItems(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{
+  "pages": {"current": 1, "total": 1},
+  "total": 3,
+  "limit": 25,
+  "tasks": [
+    {"id": 1, "name": "No case", "caseItems": []},
+    {"id": 2, "name": "Missing case"},
+    {"id": 3, "name": "Two cases", "caseItems": [
+      {"id": 10, "uId": "7000-0000-0010", "caseSubtype": "pfa", "donor": {"id": 20, "firstname": "Adrian", "surname": "Kurkjian"}},
+      {"id": 11, "uId": "7000-0000-0011", "caseSubtype": "hw", "donor": {"id": 20, "firstname": "Adrian", "surname": "Kurkjian"}}
+    ]}
+  ]
+}`))
+	}))
+	defer s.Close()
+
+	client, _ := NewClient(http.DefaultClient, s.URL)
+
+	tasks, _, err := client.TasksByAssignee(Context{Context: context.Background()}, 47, Criteria{})
+	assert.Nil(t, err)
+	assert.Len(t, tasks, 3)
+
+	for _, task := range tasks[:2] {
+		assert.False(t, task.HasCase())
+		assert.Equal(t, TaskCaseItem{}, task.Case())
+	}
+
+	assert.True(t, tasks[2].HasCase())
+	assert.Equal(t, 10, tasks[2].Case().ID)
+	assert.Equal(t, []TaskCaseItem{
+		{ID: 10, Uid: "7000-0000-0010", SubType: "pfa", Donor: Donor{ID: 20, Firstname: "Adrian", Surname: "Kurkjian"}},
+		{ID: 11, Uid: "7000-0000-0011", SubType: "hw", Donor: Donor{ID: 20, Firstname: "Adrian", Surname: "Kurkjian"}},
+	}, tasks[2].CaseItems)
+}
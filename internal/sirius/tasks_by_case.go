@@ -0,0 +1,37 @@
+package sirius
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+func (c *Client) TasksByCase(ctx Context, id int, criteria Criteria) ([]Task, error) {
+	url := fmt.Sprintf("/lpa-api/v1/cases/%d/tasks?%s", id, criteria.String())
+
+	req, err := c.newRequest(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close() //nolint:errcheck // no need to check error when closing body
+
+	if resp.StatusCode == http.StatusUnauthorized {
+		return nil, ErrUnauthorized
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, newStatusError(resp)
+	}
+
+	var v struct {
+		Tasks []Task `json:"tasks"`
+	}
+
+	err = json.NewDecoder(resp.Body).Decode(&v)
+	return v.Tasks, err
+}
@@ -0,0 +1,92 @@
+package sirius
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/pact-foundation/pact-go/v2/consumer"
+	"github.com/pact-foundation/pact-go/v2/matchers"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTasksByCase(t *testing.T) {
+	pact, err := newPact()
+	assert.NoError(t, err)
+
+	testCases := []struct {
+		name          string
+		setup         func()
+		criteria      Criteria
+		expectedTasks []Task
+		expectedError error
+	}{
+		{
+			name:     "OK",
+			criteria: Criteria{}.Filter("status", "Not started").Sort("dueDate", Ascending),
+			setup: func() {
+				pact.
+					AddInteraction().
+					Given("I have a pending case assigned which has an open task").
+					UponReceiving("A request for the tasks on a case").
+					WithCompleteRequest(consumer.Request{
+						Method: http.MethodGet,
+						Path:   matchers.String("/lpa-api/v1/cases/36/tasks"),
+						Query: matchers.MapMatcher{
+							"filter": matchers.String("status:Not started"),
+							"sort":   matchers.String("dueDate:asc"),
+						},
+					}).
+					WithCompleteResponse(consumer.Response{
+						Status:  http.StatusOK,
+						Headers: matchers.MapMatcher{"Content-Type": matchers.String("application/json")},
+						Body: matchers.Like(map[string]interface{}{
+							"tasks": matchers.EachLike(map[string]interface{}{
+								"id":      matchers.Like(12),
+								"status":  matchers.Like("Not started"),
+								"dueDate": matchers.Term("19/05/2021", `\d{1,2}/\d{1,2}/\d{4}`),
+								"name":    matchers.Like("Check application"),
+							}, 1),
+						}),
+					})
+			},
+			expectedTasks: []Task{{
+				ID:      12,
+				Status:  "Not started",
+				DueDate: SiriusDate{time.Date(2021, 5, 19, 0, 0, 0, 0, time.UTC)},
+				Name:    "Check application",
+			}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.setup()
+
+			assert.Nil(t, pact.ExecuteTest(t, func(config consumer.MockServerConfig) error {
+				client, _ := NewClient(http.DefaultClient, fmt.Sprintf("http://127.0.0.1:%d", config.Port))
+
+				tasks, err := client.TasksByCase(Context{Context: context.Background()}, 36, tc.criteria)
+				assert.Equal(t, tc.expectedTasks, tasks)
+				assert.Equal(t, tc.expectedError, err)
+				return nil
+			}))
+		})
+	}
+}
+
+func TestTasksByCaseStatusError(t *testing.T) {
+	s := teapotServer()
+	defer s.Close()
+
+	client, _ := NewClient(http.DefaultClient, s.URL)
+
+	_, err := client.TasksByCase(Context{Context: context.Background()}, 36, Criteria{})
+	assert.Equal(t, &StatusError{
+		Code:   http.StatusTeapot,
+		URL:    s.URL + "/lpa-api/v1/cases/36/tasks?",
+		Method: http.MethodGet,
+	}, err)
+}
@@ -12,7 +12,7 @@ func (c *Client) Team(ctx Context, id int) (Team, error) {
 		return Team{}, err
 	}
 
-	resp, err := c.http.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return Team{}, err
 	}
@@ -34,6 +34,7 @@ func (c *Client) Team(ctx Context, id int) (Team, error) {
 	team := Team{
 		ID:          v.ID,
 		DisplayName: v.DisplayName,
+		Type:        v.teamType(),
 	}
 
 	for _, m := range v.Members {
@@ -8,7 +8,7 @@ import (
 type apiTeam struct {
 	ID          int       `json:"id"`
 	DisplayName string    `json:"displayName"`
-	TeamType    *struct{} `json:"teamType"`
+	TeamType    *TeamType `json:"teamType"`
 	Members     []struct {
 		ID          int    `json:"id"`
 		DisplayName string `json:"displayName"`
@@ -16,14 +16,30 @@ type apiTeam struct {
 }
 
 type Team struct {
-	ID          int
-	DisplayName string
-	Members     []TeamMember
+	ID          int          `json:"id"`
+	DisplayName string       `json:"displayName"`
+	Type        TeamType     `json:"type"`
+	Members     []TeamMember `json:"members"`
+}
+
+// TeamType is the kind of team, such as "Allocations". Casework teams
+// usually have no type, in which case it is the zero value.
+type TeamType struct {
+	Handle string `json:"handle"`
+	Label  string `json:"label"`
+}
+
+func (t apiTeam) teamType() TeamType {
+	if t.TeamType == nil {
+		return TeamType{}
+	}
+
+	return *t.TeamType
 }
 
 type TeamMember struct {
-	ID          int
-	DisplayName string
+	ID          int    `json:"id"`
+	DisplayName string `json:"displayName"`
 }
 
 func (c *Client) Teams(ctx Context) ([]Team, error) {
@@ -32,7 +48,7 @@ func (c *Client) Teams(ctx Context) ([]Team, error) {
 		return nil, err
 	}
 
-	resp, err := c.http.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, err
 	}
@@ -54,13 +70,10 @@ func (c *Client) Teams(ctx Context) ([]Team, error) {
 	var teams []Team
 
 	for _, t := range v {
-		if t.TeamType != nil {
-			continue
-		}
-
 		team := Team{
 			ID:          t.ID,
 			DisplayName: t.DisplayName,
+			Type:        t.teamType(),
 			Members:     make([]TeamMember, len(t.Members)),
 		}
 
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/pact-foundation/pact-go/v2/consumer"
@@ -81,3 +82,23 @@ func TestTeamsStatusError(t *testing.T) {
 		Method: http.MethodGet,
 	}, err)
 }
+
+func TestTeamsIncludesType(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`[
+  {"id": 1, "displayName": "Casework Team 1"},
+  {"id": 2, "displayName": "Allocations", "teamType": {"handle": "ALLOCATIONS", "label": "Allocations"}}
+]`))
+	}))
+	defer s.Close()
+
+	client, _ := NewClient(http.DefaultClient, s.URL)
+
+	teams, err := client.Teams(Context{Context: context.Background()})
+	assert.Nil(t, err)
+	assert.Equal(t, []Team{
+		{ID: 1, DisplayName: "Casework Team 1", Members: []TeamMember{}},
+		{ID: 2, DisplayName: "Allocations", Type: TeamType{Handle: "ALLOCATIONS", Label: "Allocations"}, Members: []TeamMember{}},
+	}, teams)
+}
@@ -16,7 +16,7 @@ func (c *Client) User(ctx Context, id int) (Assignee, error) {
 		return v, err
 	}
 
-	resp, err := c.http.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return v, err
 	}
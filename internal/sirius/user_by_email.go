@@ -20,7 +20,7 @@ func (c *Client) UserByEmail(ctx Context, email string) (User, error) {
 		return User{}, err
 	}
 
-	resp, err := c.http.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return User{}, err
 	}
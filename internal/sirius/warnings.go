@@ -0,0 +1,40 @@
+package sirius
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+type Warning struct {
+	ID          int        `json:"id"`
+	WarningType string     `json:"warningType"`
+	WarningText string     `json:"warningText"`
+	DateAdded   SiriusDate `json:"dateAdded"`
+}
+
+// Warnings returns the warnings that are active on a person.
+func (c *Client) Warnings(ctx Context, personID int) ([]Warning, error) {
+	req, err := c.newRequest(ctx, http.MethodGet, fmt.Sprintf("/lpa-api/v1/persons/%d/warnings", personID), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close() //nolint:errcheck // no need to check error when closing body
+
+	if resp.StatusCode == http.StatusUnauthorized {
+		return nil, ErrUnauthorized
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, newStatusError(resp)
+	}
+
+	var v []Warning
+	err = json.NewDecoder(resp.Body).Decode(&v)
+	return v, err
+}
@@ -0,0 +1,84 @@
+package sirius
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/pact-foundation/pact-go/v2/consumer"
+	"github.com/pact-foundation/pact-go/v2/matchers"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWarnings(t *testing.T) {
+	pact, err := newPact()
+	assert.NoError(t, err)
+
+	testCases := []struct {
+		name             string
+		setup            func()
+		expectedWarnings []Warning
+		expectedError    error
+	}{
+		{
+			name: "OK",
+			setup: func() {
+				pact.
+					AddInteraction().
+					Given("A donor has a warning").
+					UponReceiving("A request for the donor's warnings").
+					WithCompleteRequest(consumer.Request{
+						Method: http.MethodGet,
+						Path:   matchers.String("/lpa-api/v1/persons/23/warnings"),
+					}).
+					WithCompleteResponse(consumer.Response{
+						Status:  http.StatusOK,
+						Headers: matchers.MapMatcher{"Content-Type": matchers.String("application/json")},
+						Body: matchers.EachLike(map[string]interface{}{
+							"id":          matchers.Like(5),
+							"warningType": matchers.Like("Safeguarding"),
+							"warningText": matchers.Like("Contact the donor through their attorney"),
+							"dateAdded":   matchers.Term("01/02/2021 10:00:00", `^\d{1,2}/\d{1,2}/\d{4} \d{2}:\d{2}:\d{2}$`),
+						}, 1),
+					})
+			},
+			expectedWarnings: []Warning{{
+				ID:          5,
+				WarningType: "Safeguarding",
+				WarningText: "Contact the donor through their attorney",
+				DateAdded:   SiriusDate{time.Date(2021, 2, 1, 10, 0, 0, 0, time.UTC)},
+			}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.setup()
+
+			assert.Nil(t, pact.ExecuteTest(t, func(config consumer.MockServerConfig) error {
+				client, _ := NewClient(http.DefaultClient, fmt.Sprintf("http://127.0.0.1:%d", config.Port))
+
+				warnings, err := client.Warnings(Context{Context: context.Background()}, 23)
+				assert.Equal(t, tc.expectedWarnings, warnings)
+				assert.Equal(t, tc.expectedError, err)
+				return nil
+			}))
+		})
+	}
+}
+
+func TestWarningsStatusError(t *testing.T) {
+	s := teapotServer()
+	defer s.Close()
+
+	client, _ := NewClient(http.DefaultClient, s.URL)
+
+	_, err := client.Warnings(Context{Context: context.Background()}, 23)
+	assert.Equal(t, &StatusError{
+		Code:   http.StatusTeapot,
+		URL:    s.URL + "/lpa-api/v1/persons/23/warnings",
+		Method: http.MethodGet,
+	}, err)
+}
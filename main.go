@@ -2,18 +2,22 @@ package main
 
 import (
 	"context"
+	"crypto/rand"
+	"fmt"
 	"html/template"
 	"log/slog"
 	"net/http"
 	"os"
 	"os/signal"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"syscall"
 	"time"
 
 	"github.com/ministryofjustice/opg-go-common/env"
 	"github.com/ministryofjustice/opg-go-common/telemetry"
+	"github.com/ministryofjustice/opg-sirius-lpa-dashboard/internal/presets"
 	"github.com/ministryofjustice/opg-sirius-lpa-dashboard/internal/server"
 	"github.com/ministryofjustice/opg-sirius-lpa-dashboard/internal/sirius"
 	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
@@ -37,6 +41,21 @@ func run(ctx context.Context, logger *slog.Logger) error {
 	prefix := env.Get("PREFIX", "")
 	exportTraces := env.Get("TRACING_ENABLED", "0") == "1"
 
+	myDetailsTTL, err := time.ParseDuration(env.Get("MY_DETAILS_CACHE_TTL", "30s"))
+	if err != nil {
+		return fmt.Errorf("invalid MY_DETAILS_CACHE_TTL: %w", err)
+	}
+
+	caseworkTeams, err := server.ParseTeamSelector(env.Get("CASEWORK_TEAMS", server.DefaultCaseworkTeams))
+	if err != nil {
+		return fmt.Errorf("invalid CASEWORK_TEAMS: %w", err)
+	}
+
+	presetStore, err := presets.NewFileStore(env.Get("FILTER_PRESETS_FILE", ""))
+	if err != nil {
+		return fmt.Errorf("invalid FILTER_PRESETS_FILE: %w", err)
+	}
+
 	layouts, _ := template.
 		New("").
 		Funcs(map[string]interface{}{
@@ -73,6 +92,10 @@ func run(ctx context.Context, logger *slog.Logger) error {
 				case time.Time:
 					return t.Format("02 Jan 2006")
 				case sirius.SiriusDate:
+					if t.IsZero() {
+						return ""
+					}
+
 					return t.Format("02 Jan 2006")
 				default:
 					panic("can't format date")
@@ -116,7 +139,15 @@ func run(ctx context.Context, logger *slog.Logger) error {
 		return err
 	}
 
-	httpClient := http.DefaultClient
+	httpConfig, err := siriusHTTPConfig()
+	if err != nil {
+		return err
+	}
+
+	httpClient, err := sirius.NewHTTPClient(httpConfig)
+	if err != nil {
+		return err
+	}
 	httpClient.Transport = otelhttp.NewTransport(httpClient.Transport)
 
 	client, err := sirius.NewClient(httpClient, siriusURL)
@@ -124,9 +155,34 @@ func run(ctx context.Context, logger *slog.Logger) error {
 		return err
 	}
 
+	assignChunkSize, err := strconv.Atoi(env.Get("SIRIUS_ASSIGN_CHUNK_SIZE", "20"))
+	if err != nil {
+		return fmt.Errorf("invalid SIRIUS_ASSIGN_CHUNK_SIZE: %w", err)
+	}
+	client.SetAssignChunkSize(assignChunkSize)
+
+	pageSize, err := strconv.Atoi(env.Get("SIRIUS_PAGE_SIZE", "100"))
+	if err != nil {
+		return fmt.Errorf("invalid SIRIUS_PAGE_SIZE: %w", err)
+	}
+	client.SetPageSize(pageSize)
+
+	var feedbackOutbox *server.FeedbackOutbox
+	if token := env.Get("SIRIUS_FEEDBACK_TOKEN", ""); token != "" {
+		feedbackOutbox, err = server.NewFeedbackOutbox(client, logger, token, env.Get("FEEDBACK_OUTBOX_FILE", ""))
+		if err != nil {
+			return fmt.Errorf("invalid FEEDBACK_OUTBOX_FILE: %w", err)
+		}
+	}
+
+	undoKey := env.Get("UNDO_SIGNING_KEY", "")
+	if undoKey == "" {
+		undoKey = rand.Text()
+	}
+
 	server := &http.Server{
 		Addr:              ":" + port,
-		Handler:           server.New(logger, client, tmpls, prefix, siriusURL, siriusPublicURL, webDir),
+		Handler:           server.New(logger, client, tmpls, prefix, siriusURL, siriusPublicURL, webDir, myDetailsTTL, caseworkTeams, presetStore, feedbackOutbox, []byte(undoKey)),
 		ReadHeaderTimeout: 10 * time.Second,
 	}
 
@@ -150,3 +206,36 @@ func run(ctx context.Context, logger *slog.Logger) error {
 
 	return server.Shutdown(tc)
 }
+
+func siriusHTTPConfig() (sirius.HTTPConfig, error) {
+	config := sirius.DefaultHTTPConfig
+	config.CABundlePath = env.Get("SIRIUS_CA_BUNDLE", "")
+	config.ProxyURL = env.Get("SIRIUS_PROXY_URL", "")
+
+	durations := map[string]*time.Duration{
+		"SIRIUS_TIMEOUT":                 &config.Timeout,
+		"SIRIUS_DIAL_TIMEOUT":            &config.DialTimeout,
+		"SIRIUS_TLS_HANDSHAKE_TIMEOUT":   &config.TLSHandshakeTimeout,
+		"SIRIUS_RESPONSE_HEADER_TIMEOUT": &config.ResponseHeaderTimeout,
+	}
+
+	for name, d := range durations {
+		if v := env.Get(name, ""); v != "" {
+			parsed, err := time.ParseDuration(v)
+			if err != nil {
+				return config, fmt.Errorf("invalid %s: %w", name, err)
+			}
+			*d = parsed
+		}
+	}
+
+	if v := env.Get("SIRIUS_MAX_IDLE_CONNS_PER_HOST", ""); v != "" {
+		parsed, err := strconv.Atoi(v)
+		if err != nil {
+			return config, fmt.Errorf("invalid SIRIUS_MAX_IDLE_CONNS_PER_HOST: %w", err)
+		}
+		config.MaxIdleConnsPerHost = parsed
+	}
+
+	return config, nil
+}
@@ -85,6 +85,125 @@ function initFilterHeadings() {
   }
 }
 
+function initTypeahead() {
+  const select = document.querySelector("select[data-typeahead]");
+  if (!select) {
+    return;
+  }
+
+  const url = select.getAttribute("data-typeahead");
+
+  const input = document.createElement("input");
+  input.type = "search";
+  input.id = select.id + "-search";
+  input.className = "govuk-input govuk-!-margin-bottom-2";
+  input.autocomplete = "off";
+  input.setAttribute("aria-label", "Search for a caseworker in any team");
+  input.setAttribute("aria-controls", select.id + "-results");
+
+  const results = document.createElement("ul");
+  results.id = select.id + "-results";
+  results.className = "govuk-list";
+  results.setAttribute("aria-live", "polite");
+
+  select.parentNode.insertBefore(input, select);
+  select.parentNode.insertBefore(results, select);
+
+  const choose = (user) => {
+    let option = Array.from(select.options).find(
+      (x) => x.value === String(user.id),
+    );
+    if (!option) {
+      option = new Option(user.displayName, user.id);
+      select.add(option);
+    }
+
+    select.value = String(user.id);
+    input.value = user.displayName;
+    results.replaceChildren();
+  };
+
+  let timeout;
+  input.oninput = () => {
+    clearTimeout(timeout);
+    timeout = setTimeout(async () => {
+      const term = input.value.trim();
+      if (term.length < 3) {
+        results.replaceChildren();
+        return;
+      }
+
+      const response = await fetch(`${url}?q=${encodeURIComponent(term)}`, {
+        credentials: "same-origin",
+        headers: { Accept: "application/json" },
+      });
+      if (!response.ok) {
+        return;
+      }
+
+      const users = await response.json();
+      results.replaceChildren(
+        ...users.map((user) => {
+          const button = document.createElement("button");
+          button.type = "button";
+          button.className =
+            "govuk-button govuk-button--secondary govuk-!-margin-bottom-1";
+          button.textContent = user.team
+            ? `${user.displayName} (${user.team})`
+            : user.displayName;
+          button.onclick = () => choose(user);
+
+          const item = document.createElement("li");
+          item.appendChild(button);
+          return item;
+        }),
+      );
+    }, 250);
+  };
+}
+
+function initCaseSummary() {
+  const links = document.querySelectorAll("a[data-case-summary]");
+
+  for (const link of links) {
+    const row = link.closest("tr");
+    let summaryRow;
+
+    link.setAttribute("aria-expanded", "false");
+
+    link.onclick = async (event) => {
+      event.preventDefault();
+
+      if (summaryRow) {
+        summaryRow.hidden = !summaryRow.hidden;
+        link.setAttribute("aria-expanded", String(!summaryRow.hidden));
+        return;
+      }
+
+      const response = await fetch(link.href, {
+        credentials: "same-origin",
+        headers: { "X-Requested-With": "XMLHttpRequest" },
+      });
+      if (!response.ok) {
+        window.location.assign(link.href);
+        return;
+      }
+
+      const cell = document.createElement("td");
+      cell.className = "govuk-table__cell";
+      cell.colSpan = row.cells.length;
+      cell.innerHTML = await response.text();
+
+      summaryRow = document.createElement("tr");
+      summaryRow.className = "govuk-table__row app-case-summary-row";
+      summaryRow.appendChild(cell);
+      row.after(summaryRow);
+
+      link.setAttribute("aria-expanded", "true");
+    };
+  }
+}
+
 // we aren't using the JS tabs, but they try to initialise this will stop them breaking
 GOVUKFrontend.Tabs.prototype.setup = () => {};
 
@@ -95,3 +214,5 @@ initSelectShow();
 initSelectNavigate();
 initFilterToggle();
 initFilterHeadings();
+initTypeahead();
+initCaseSummary();